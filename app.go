@@ -4,15 +4,16 @@ package main
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
-	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/wailsapp/wails/v2/pkg/runtime"
@@ -27,12 +28,13 @@ type App struct {
 	gitPath      string       // 自定义 git 路径，为空则使用系统默认路径
 	userInfo     *UserInfo    // 当前登录的 GitHub 用户信息
 	oauthServer  *http.Server // 用于接收 OAuth 回调的临时 HTTP 服务器
+	userInfoMu   sync.Mutex   // 保护后台任务对 userInfo 的加载
 }
 
 // NewApp 创建并返回一个新的 App 实例。
 func NewApp() *App {
 	return &App{
-		repoBasePath: filepath.Join(os.TempDir(), "green-wall"),
+		repoBasePath: defaultWorkspacePath(),
 	}
 }
 
@@ -40,6 +42,7 @@ func NewApp() *App {
 // 它负责保存应用上下文，以便后续调用前端运行时方法。
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
+	a.startDripScheduler()
 }
 
 // Greet 是一个演示用的问候方法。
@@ -68,12 +71,48 @@ type GenerateRepoRequest struct {
 	Language        string            `json:"language"`        // 默认编程语言(单语言模式)
 	LanguageConfigs []LanguageConfig  `json:"languageConfigs"` // 多语言配置(多语言模式)
 	MultiLanguage   bool              `json:"multiLanguage"`   // 是否启用多语言混合生成
+	// DeltaMode 为 true 时，Contributions 视为目标图案，只生成在已有贡献基础上所需的额外提交
+	DeltaMode             bool              `json:"deltaMode"`
+	ExistingContributions []ContributionDay `json:"existingContributions"` // 增量模式下的已有贡献，为空时从 GitHub 获取
+	// 签名、提交归属、提交说明、文件布局与提交时间等选项
+	GenerationOptions
+}
+
+// GenerationOptions 是 GenerateRepo 与 GenerateMultiYearRepo 共用的生成选项，
+// 嵌入请求结构体后字段在 JSON 中与其他请求字段平铺。
+type GenerationOptions struct {
+	// Signing 非空时为每个生成的提交添加 GPG 或 SSH 签名
+	Signing *SigningOptions `json:"signing,omitempty"`
+	// Authors 非空时按权重或指定日期将提交分配给多位作者，否则全部归属 GithubUsername/GithubEmail
+	Authors   []WeightedIdentity `json:"authors"`
+	Committer *GitIdentity       `json:"committer,omitempty"` // 提交者，为空时与作者相同
+	CoAuthors []GitIdentity      `json:"coAuthors"`           // 为每个提交添加的 Co-authored-by 尾注
+	// CommitMessage 非空时使用模板生成提交说明
+	CommitMessage *CommitMessageOptions `json:"commitMessage,omitempty"`
+	// FileLayout 决定代码写入的文件：single（默认）、daily、monthly 或 append
+	FileLayout string `json:"fileLayout"`
+	// ContentMode 为 append 时每个提交只在文件末尾追加一段代码，diff 只包含新增内容；默认 full
+	ContentMode string `json:"contentMode"`
+	// Seed 非 0 时在白天时段内生成可复现的提交时间；相同的请求与种子生成相同的提交 SHA（签名提交除外）
+	Seed int64 `json:"seed"`
+	// ScaffoldDate 为空时模板的额外文件（如 go.mod、.gitignore）随第一个贡献提交加入，不改变任何格子的提交数；
+	// 非空时 (YYYY-MM-DD) 必须早于第一个贡献所在的年份，额外文件在该日期单独提交，计入提交总数
+	ScaffoldDate string `json:"scaffoldDate"`
 }
 
 // GenerateRepoResponse 返回生成结果。
 type GenerateRepoResponse struct {
-	RepoPath    string `json:"repoPath"`    // 仓库在本地的临时存储路径
-	CommitCount int    `json:"commitCount"` // 成功生成的总提交数
+	RepoPath      string `json:"repoPath"`      // 仓库在工作区中的路径
+	WorkspaceName string `json:"workspaceName"` // 工作区中的项目名，用于重新打开、重试推送或删除
+	CommitCount   int    `json:"commitCount"`   // 成功生成的总提交数，含单独的脚手架提交
+	// ScaffoldCommit 表示在绘制年份之前额外生成了一个脚手架提交
+	ScaffoldCommit bool `json:"scaffoldCommit"`
+	// Author 是提交实际使用的作者身份及其来源
+	Author CommitIdentity `json:"author"`
+	// AuthorCommits 统计每位作者（按邮箱）分配到的提交数
+	AuthorCommits map[string]int `json:"authorCommits"`
+	// DeltaWarnings 列出增量模式下无法呈现目标色阶的格子
+	DeltaWarnings []ShadingWarning `json:"deltaWarnings,omitempty"`
 }
 
 var repoNameSanitiser = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
@@ -185,105 +224,46 @@ func (a *App) getGitCommand() string {
 // GenerateRepo 是核心方法，它会根据前端提供的贡献图数据，在本地生成一个具有对应历史记录的 Git 仓库。
 // 该方法使用了 git fast-import 技术以实现极高性能的历史注入。
 func (a *App) GenerateRepo(req GenerateRepoRequest) (*GenerateRepoResponse, error) {
-	// 处理语言配置
-	var languageConfigs []LanguageConfig
-	if req.MultiLanguage && len(req.LanguageConfigs) > 0 {
-		// 多语言模式
-		languageConfigs = req.LanguageConfigs
-		
-		// 验证语言配置
-		if err := validateLanguageConfigs(languageConfigs); err != nil {
-			LogError("语言配置验证失败", zap.Error(err))
-			return nil, fmt.Errorf("invalid language configs: %w", err)
-		}
-		
-		// 标准化语言配置
-		languageConfigs = normalizeLanguageConfigs(languageConfigs)
-		
-		LogInfo("开始生成仓库(多语言模式)", 
-			zap.Int("contributions_count", len(req.Contributions)),
-			zap.String("username", req.GithubUsername),
-			zap.Int("year", req.Year),
-			zap.Int("language_count", len(languageConfigs)))
-	} else {
-		// 单语言模式(向后兼容)
-		language := req.Language
-		if language == "" {
-			language = "markdown"
-		}
-		languageConfigs = []LanguageConfig{{Language: language, Ratio: 100}}
-		LogInfo("开始生成仓库(单语言模式)", 
-			zap.Int("contributions_count", len(req.Contributions)),
-			zap.String("username", req.GithubUsername),
-			zap.Int("year", req.Year),
-			zap.String("language", language))
-	}
-	
-	if len(req.Contributions) == 0 {
-		LogError("生成仓库失败：无贡献数据")
-		return nil, fmt.Errorf("no contributions supplied")
-	}
-
-	totalRequestedCommits := 0
-	for _, c := range req.Contributions {
-		if c.Count < 0 {
-			LogError("无效的贡献计数", zap.String("date", c.Date), zap.Int("count", c.Count))
-			return nil, fmt.Errorf("invalid contribution count for %s: %d", c.Date, c.Count)
-		}
-		totalRequestedCommits += c.Count
-	}
-	if totalRequestedCommits == 0 {
-		LogWarn("生成仓库失败：无提交需要生成")
-		return nil, fmt.Errorf("no commits to generate")
-	}
-
-	LogInfo("计算提交总数", zap.Int("total_commits", totalRequestedCommits))
-
-	username := strings.TrimSpace(req.GithubUsername)
-	if username == "" {
-		username = "Cail Gainey"
-	}
-	email := strings.TrimSpace(req.GithubEmail)
-	if email == "" {
-		email = "cailgainey@foxmail.com"
-	}
-
-	if err := os.MkdirAll(a.repoBasePath, 0o755); err != nil {
-		return nil, fmt.Errorf("create repo base directory: %w", err)
-	}
-
-	repoName := strings.TrimSpace(req.RepoName)
-	if repoName == "" {
-		repoName = username
-		if req.Year > 0 {
-			repoName = fmt.Sprintf("%s-%d", repoName, req.Year)
-		}
-	}
-	repoName = sanitiseRepoName(repoName)
-	if repoName == "" {
-		repoName = "contributions"
+	planner, err := a.newGenerationPlanner(req)
+	if err != nil {
+		LogError("生成计划失败", zap.Error(err))
+		return nil, err
 	}
-
-	repoPath, err := os.MkdirTemp(a.repoBasePath, repoName+"-")
+	plan := planner.plan
+	username, email := plan.Author.Name, plan.Author.Email
+	repoName := plan.RepoName
+	LogInfo("开始生成仓库",
+		zap.Int("contributions_count", len(req.Contributions)),
+		zap.String("username", username),
+		zap.Int("year", req.Year),
+		zap.Int("total_commits", plan.CommitCount),
+		zap.Int("language_count", len(planner.languageConfigs)))
+
+	repoPath, err := a.createWorkspaceRepo(repoName)
 	if err != nil {
 		LogError("创建仓库目录失败", zap.Error(err))
 		return nil, fmt.Errorf("create repo directory: %w", err)
 	}
+	// 生成失败时清理不完整的仓库，成功的仓库保留在工作区中
+	generated := false
+	defer func() {
+		if !generated {
+			os.RemoveAll(repoPath)
+		}
+	}()
 
 	LogInfo("创建仓库目录", zap.String("path", repoPath), zap.String("repo_name", repoName))
 
 	// 生成多语言README
 	readmePath := filepath.Join(repoPath, "README.md")
-	readmeContent := generateMultiLanguageReadme(repoName, languageConfigs)
-	if err := os.WriteFile(readmePath, []byte(readmeContent), 0o644); err != nil {
+	if err := os.WriteFile(readmePath, []byte(plan.readme), 0o644); err != nil {
 		LogError("写入README失败", zap.Error(err))
 		return nil, fmt.Errorf("write README: %w", err)
 	}
 	
 	// 创建所有语言的额外文件
-	allAdditionalFiles := mergeAdditionalFiles(repoName, languageConfigs)
-	
-	for filePath, content := range allAdditionalFiles {
+	for _, filePath := range sortedFilePaths(plan.scaffold) {
+		content := plan.scaffold[filePath]
 		fullPath := filepath.Join(repoPath, filePath)
 		// 确保目录存在
 		if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
@@ -310,76 +290,44 @@ func (a *App) GenerateRepo(req GenerateRepoRequest) (*GenerateRepoResponse, erro
 
     // 优化：使用git fast-import以避免为每个提交启动一个进程。
     // 同时禁用此仓库的慢速功能。
-    _ = a.runGitCommand(repoPath, "config", "commit.gpgsign", "false")
+    // 需要签名时由导入后的 signCommits 逐个签名，否则关闭签名以免全局配置拖慢生成
+    if req.Signing != nil {
+        if err := a.configureSigning(repoPath, req.Signing); err != nil {
+            LogError("配置提交签名失败", zap.Error(err))
+            return nil, err
+        }
+    } else {
+        _ = a.runGitCommand(repoPath, "config", "commit.gpgsign", "false")
+    }
     _ = a.runGitCommand(repoPath, "config", "gc.auto", "0")
     _ = a.runGitCommand(repoPath, "config", "core.autocrlf", "false")
     _ = a.runGitCommand(repoPath, "config", "core.fsync", "none")
 
-    // 按日期升序排序贡献以生成时间线历史
-    contribs := make([]ContributionDay, 0, len(req.Contributions))
-    for _, c := range req.Contributions {
-        if c.Count > 0 {
-            contribs = append(contribs, c)
-        }
-    }
-    sort.Slice(contribs, func(i, j int) bool { return contribs[i].Date < contribs[j].Date })
-
-    // 构建fast-import流
-    var stream bytes.Buffer
-    // 创建README blob并标记它
-    fmt.Fprintf(&stream, "blob\nmark :1\n")
-    fmt.Fprintf(&stream, "data %d\n%s\n", len(readmeContent), readmeContent)
-
-    nextMark := 2
-    totalCommits := 0
+    // 边计算边将fast-import流通过管道发送给git，不在内存中保留整个流
     branch := "refs/heads/main"
-
-    for _, day := range contribs {
-        parsedDate, err := time.Parse("2006-01-02", day.Date)
-        if err != nil {
-            return nil, fmt.Errorf("invalid date %q: %w", day.Date, err)
+    totalCommits := plan.CommitCount
+    if totalCommits > 0 {
+        pr, pw := io.Pipe()
+        streamErr := make(chan error, 1)
+        go func() {
+            err := planner.writeFastImport(pw, branch)
+            pw.CloseWithError(err)
+            streamErr <- err
+        }()
+        importErr := a.runGitFastImport(repoPath, pr)
+        // fast-import 提前退出时关闭读端，避免写入方一直阻塞
+        pr.Close()
+        if err := <-streamErr; err != nil && !errors.Is(err, io.ErrClosedPipe) {
+            return nil, fmt.Errorf("build fast-import stream: %w", err)
         }
-        for i := 0; i < day.Count; i++ {
-            // 根据比例选择语言
-            selectedLang := selectLanguageByRatio(languageConfigs, totalCommits)
-            template := languages.GetLanguageTemplate(languages.LanguageType(selectedLang))
-            
-            // 使用语言模板生成代码内容
-            codeContent := template.GenerateCode(day.Date, i+1, day.Count)
-
-            // 发射代码文件的blob
-            fmt.Fprintf(&stream, "blob\nmark :%d\n", nextMark)
-            fmt.Fprintf(&stream, "data %d\n", len(codeContent))
-            stream.WriteString(codeContent)
-            stream.WriteString("\n")
-
-            // 获取代码文件路径（相对于仓库根目录）
-            codeFilePath := languages.GetCodeFilePath("", languages.LanguageType(selectedLang), day.Date, i+1)
-            // 只需要相对路径，去掉开头的路径分隔符
-            codeFilePath = strings.TrimPrefix(codeFilePath, string(filepath.Separator))
-
-            // 发射提交，指向README (:1)和代码文件 (:nextMark)
-            commitTime := parsedDate.Add(time.Duration(i) * time.Second)
-            secs := commitTime.Unix()
-            tz := commitTime.Format("-0700")
-            msg := fmt.Sprintf("Contribution on %s (%d/%d)", day.Date, i+1, day.Count)
-            fmt.Fprintf(&stream, "commit %s\n", branch)
-            fmt.Fprintf(&stream, "author %s <%s> %d %s\n", username, email, secs, tz)
-            fmt.Fprintf(&stream, "committer %s <%s> %d %s\n", username, email, secs, tz)
-            fmt.Fprintf(&stream, "data %d\n%s\n", len(msg), msg)
-            fmt.Fprintf(&stream, "M 100644 :1 %s\n", filepath.Base(readmePath))
-            fmt.Fprintf(&stream, "M 100644 :%d %s\n", nextMark, codeFilePath)
-
-            nextMark++
-            totalCommits++
+        if importErr != nil {
+            return nil, fmt.Errorf("fast-import failed: %w", importErr)
         }
-    }
-    stream.WriteString("done\n")
-
-    // 将流发送到fast-import
-    if totalCommits > 0 {
-        if err := a.runGitFastImport(repoPath, &stream); err != nil {
-            return nil, fmt.Errorf("fast-import failed: %w", err)
+        if req.Signing != nil {
+            if _, err := a.signCommits(repoPath, "", branch); err != nil {
+                LogError("签名提交失败", zap.Error(err))
+                return nil, err
+            }
         }
         // 更新工作目录到生成的分支，为用户方便
         _ = a.runGitCommand(repoPath, "checkout", "-f", "main")
@@ -391,20 +339,39 @@ func (a *App) GenerateRepo(req GenerateRepoRequest) (*GenerateRepoResponse, erro
 	}
 	*/
 
+	if err := writeWorkspaceMetadata(&WorkspaceRepo{
+		Name:        filepath.Base(repoPath),
+		Path:        repoPath,
+		Year:        req.Year,
+		CommitCount: totalCommits,
+		Status:      workspaceStatusGenerated,
+		CreatedAt:   time.Now().Format(time.RFC3339),
+	}); err != nil {
+		LogError("写入工作区元数据失败", zap.Error(err))
+		return nil, err
+	}
+	generated = true
+
 	LogInfo("仓库生成成功", 
 		zap.String("repo_path", repoPath),
 		zap.Int("commit_count", totalCommits),
 		zap.String("repo_name", repoName))
 	
 	return &GenerateRepoResponse{
-		RepoPath:    repoPath,
-		CommitCount: totalCommits,
+		RepoPath:       repoPath,
+		WorkspaceName:  filepath.Base(repoPath),
+		CommitCount:    totalCommits,
+		ScaffoldCommit: plan.ScaffoldCommit,
+		Author:         plan.Author,
+		AuthorCommits:  plan.AuthorCommits,
+		DeltaWarnings:  plan.DeltaWarnings,
 	}, nil
 }
 
 // ExportContributionsRequest 定义导出请求。
 type ExportContributionsRequest struct {
 	Contributions []ContributionDay `json:"contributions"`
+	Format        string            `json:"format"` // 导出格式：json/csv/tsv，为空时根据文件扩展名决定
 }
 
 // ExportContributionsResponse 定义导出结果。
@@ -414,24 +381,35 @@ type ExportContributionsResponse struct {
 	FilePath string `json:"filePath"` // 导出的文件路径
 }
 
-// ExportContributions 将当前的贡献图数据导出为 JSON 文件。
+// contributionFileFilters 返回导入导出对话框的文件过滤器，首选格式排在最前。
+func contributionFileFilters(preferred string) []runtime.FileFilter {
+	filters := []runtime.FileFilter{
+		{DisplayName: "JSON Files (*.json)", Pattern: "*.json"},
+		{DisplayName: "CSV Files (*.csv)", Pattern: "*.csv"},
+		{DisplayName: "TSV Files (*.tsv)", Pattern: "*.tsv"},
+	}
+	for i, f := range filters {
+		if f.Pattern == "*."+preferred {
+			filters[0], filters[i] = filters[i], filters[0]
+		}
+	}
+	return append(filters, runtime.FileFilter{DisplayName: "All Files (*.*)", Pattern: "*.*"})
+}
+
+// ExportContributions 将当前的贡献图数据导出为 JSON、CSV 或 TSV 文件。
 func (a *App) ExportContributions(req ExportContributionsRequest) (*ExportContributionsResponse, error) {
-	LogInfo("开始导出贡献数据", zap.Int("count", len(req.Contributions)))
-	
-	data, err := json.MarshalIndent(req.Contributions, "", "  ")
-	if err != nil {
-		LogError("序列化贡献数据失败", zap.Error(err))
-		return nil, fmt.Errorf("marshal contributions: %w", err)
+	LogInfo("开始导出贡献数据", zap.Int("count", len(req.Contributions)), zap.String("format", req.Format))
+
+	preferred := strings.ToLower(strings.TrimSpace(req.Format))
+	if preferred == "" {
+		preferred = contributionFormatJSON
 	}
 
 	// 使用对话框让用户选择保存位置
 	filePath, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
 		Title:           "导出贡献数据",
-		DefaultFilename: "contributions.json",
-		Filters: []runtime.FileFilter{
-			{DisplayName: "JSON Files (*.json)", Pattern: "*.json"},
-			{DisplayName: "All Files (*.*)", Pattern: "*.*"},
-		},
+		DefaultFilename: "contributions." + preferred,
+		Filters:         contributionFileFilters(preferred),
 	})
 
 	if err != nil {
@@ -448,12 +426,19 @@ func (a *App) ExportContributions(req ExportContributionsRequest) (*ExportContri
 		}, nil
 	}
 
+	format := detectContributionFormat(req.Format, filePath, nil)
+	data, err := encodeContributions(req.Contributions, format)
+	if err != nil {
+		LogError("序列化贡献数据失败", zap.Error(err))
+		return nil, fmt.Errorf("marshal contributions: %w", err)
+	}
+
 	if err := os.WriteFile(filePath, data, 0o644); err != nil {
 		LogError("写入文件失败", zap.String("path", filePath), zap.Error(err))
 		return nil, fmt.Errorf("write file: %w", err)
 	}
 
-	LogInfo("导出贡献数据成功", zap.String("path", filePath), zap.Int("size", len(data)))
+	LogInfo("导出贡献数据成功", zap.String("path", filePath), zap.String("format", format), zap.Int("size", len(data)))
 	return &ExportContributionsResponse{
 		Success:  true,
 		Message:  "导出成功",
@@ -461,20 +446,33 @@ func (a *App) ExportContributions(req ExportContributionsRequest) (*ExportContri
 	}, nil
 }
 
+// ImportContributionsRequest 定义导入选项。
+type ImportContributionsRequest struct {
+	Format        string `json:"format"`        // 文件格式：json/csv/tsv，为空时自动识别
+	MergeStrategy string `json:"mergeStrategy"` // 重复日期的合并策略：sum/max/last，默认 last
+}
+
 // ImportContributionsResponse 定义导入结果。
 type ImportContributionsResponse struct {
-	Contributions []ContributionDay `json:"contributions"` // 导入的贡献数据
+	Contributions []ContributionDay `json:"contributions"`    // 导入的贡献数据
+	Errors        []ImportRowError  `json:"errors,omitempty"` // 解析失败的行，非空时不返回任何数据
 }
 
-// ImportContributions 从本地 JSON 文件导入贡献图数据。
+// ImportContributions 从本地 JSON/CSV/TSV 文件导入贡献图数据，使用默认选项。
 func (a *App) ImportContributions() (*ImportContributionsResponse, error) {
-	LogInfo("开始导入贡献数据")
-	
+	return a.ImportContributionsWithOptions(ImportContributionsRequest{})
+}
+
+// ImportContributionsWithOptions 从本地文件导入贡献图数据。
+// 文件中的错误行会被完整收集并通过响应中的 Errors 字段返回，便于前端逐行提示。
+func (a *App) ImportContributionsWithOptions(req ImportContributionsRequest) (*ImportContributionsResponse, error) {
+	LogInfo("开始导入贡献数据", zap.String("format", req.Format), zap.String("merge_strategy", req.MergeStrategy))
+
 	// 使用对话框让用户选择导入文件
 	filePath, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
 		Title: "导入贡献数据",
 		Filters: []runtime.FileFilter{
-			{DisplayName: "JSON 文件 (*.json)", Pattern: "*.json"},
+			{DisplayName: "贡献数据 (*.json, *.csv, *.tsv)", Pattern: "*.json;*.csv;*.tsv"},
 		},
 	})
 	if err != nil {
@@ -493,13 +491,19 @@ func (a *App) ImportContributions() (*ImportContributionsResponse, error) {
 		return nil, fmt.Errorf("read contributions file: %w", err)
 	}
 
-	var contributions []ContributionDay
-	if err := json.Unmarshal(data, &contributions); err != nil {
-		LogError("解析JSON失败", zap.Error(err))
-		return nil, fmt.Errorf("unmarshal contributions: %w", err)
+	format := detectContributionFormat(req.Format, filePath, data)
+	contributions, err := parseContributions(data, format, req.MergeStrategy)
+	if err != nil {
+		var importErr *ContributionImportError
+		if errors.As(err, &importErr) {
+			LogWarn("导入文件包含错误行", zap.String("format", format), zap.Int("rows", len(importErr.Rows)))
+			return &ImportContributionsResponse{Errors: importErr.Rows}, nil
+		}
+		LogError("解析贡献数据失败", zap.Error(err))
+		return nil, fmt.Errorf("parse contributions: %w", err)
 	}
 
-	LogInfo("导入贡献数据成功", zap.Int("count", len(contributions)))
+	LogInfo("导入贡献数据成功", zap.String("format", format), zap.Int("count", len(contributions)))
 	return &ImportContributionsResponse{Contributions: contributions}, nil
 }
 
@@ -537,10 +541,51 @@ func (a *App) runGitCommand(dir string, args ...string) error {
 	return nil
 }
 
+// runGitOutput 在指定目录执行 Git 命令并返回去掉首尾空白的标准输出。
+func (a *App) runGitOutput(dir string, args ...string) (string, error) {
+	gitCmd := a.getGitCommand()
+	cmd := exec.Command(gitCmd, args...)
+	cmd.Dir = dir
+	configureCommand(cmd, true)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w (%s)", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// runGitInput 在指定目录执行 Git 命令，stdin 作为标准输入，env 追加到当前环境变量之后，返回标准输出。
+func (a *App) runGitInput(dir string, env []string, stdin io.Reader, args ...string) (string, error) {
+	gitCmd := a.getGitCommand()
+	cmd := exec.Command(gitCmd, args...)
+	cmd.Dir = dir
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	configureCommand(cmd, true)
+	cmd.Stdin = stdin
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w (%s)", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
+
 // runGitFastImport 通过标准输入运行 `git fast-import` 命令，直接注入提交历史。
-func (a *App) runGitFastImport(dir string, r *bytes.Buffer) error {
+// extraArgs 会追加到 fast-import 的参数之后，例如允许非快进更新的 --force。
+func (a *App) runGitFastImport(dir string, r io.Reader, extraArgs ...string) error {
     gitCmd := a.getGitCommand()
-    cmd := exec.Command(gitCmd, "fast-import", "--quiet")
+    cmd := exec.Command(gitCmd, append([]string{"fast-import", "--quiet"}, extraArgs...)...)
     cmd.Dir = dir
     configureCommand(cmd, true)
     cmd.Stdin = r
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"os/exec"
+	"testing"
+)
+
+// newTestApp 返回使用临时配置目录与工作区的 App，未安装 git 时跳过测试。
+func newTestApp(t *testing.T) *App {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	configDir := t.TempDir()
+	t.Setenv("HOME", configDir)
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("AppData", configDir)
+	return NewApp()
+}
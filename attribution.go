@@ -0,0 +1,139 @@
+// attribution.go 支持多人共同绘制贡献图：提交按权重或指定日期分配给多个作者，
+// 提交者可以与作者不同，并可为每个提交添加 Co-authored-by 尾注。
+package main
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// GitIdentity 是一个 git 身份（名称与邮箱）。
+type GitIdentity struct {
+	Name  string `json:"name"`  // 名称
+	Email string `json:"email"` // 邮箱
+}
+
+// WeightedIdentity 是参与分配提交的作者。
+type WeightedIdentity struct {
+	Name   string   `json:"name"`   // 作者名称
+	Email  string   `json:"email"`  // 作者邮箱
+	Weight int      `json:"weight"` // 分配权重，0 视为 1
+	Dates  []string `json:"dates"`  // 指定日期 (YYYY-MM-DD) 的提交全部归属该作者，优先于权重
+}
+
+// commitAttribution 决定每个生成提交的作者、提交者与尾注。
+type commitAttribution struct {
+	authors     []WeightedIdentity
+	byDate      map[string]int // 日期 -> authors 下标
+	totalWeight int
+	committer   *GitIdentity
+	coAuthors   []GitIdentity
+	weighted    int            // 已按权重分配的提交数
+	counts      map[string]int // 作者邮箱 -> 提交数
+}
+
+// newCommitAttribution 校验并构建提交归属规则。authors 为空时所有提交归属 fallback。
+func newCommitAttribution(fallback GitIdentity, authors []WeightedIdentity, committer *GitIdentity, coAuthors []GitIdentity) (*commitAttribution, error) {
+	if len(authors) == 0 {
+		authors = []WeightedIdentity{{Name: fallback.Name, Email: fallback.Email, Weight: 1}}
+	}
+	attr := &commitAttribution{
+		byDate: make(map[string]int),
+		counts: make(map[string]int),
+	}
+	for i, author := range authors {
+		author.Name = strings.TrimSpace(author.Name)
+		author.Email = strings.TrimSpace(author.Email)
+		if err := validateGitIdentity(GitIdentity{Name: author.Name, Email: author.Email}); err != nil {
+			return nil, fmt.Errorf("author %d: %w", i+1, err)
+		}
+		if author.Weight < 0 {
+			return nil, fmt.Errorf("author %s: invalid weight %d", author.Email, author.Weight)
+		}
+		if author.Weight == 0 {
+			author.Weight = 1
+		}
+		for _, date := range author.Dates {
+			if _, err := time.Parse("2006-01-02", date); err != nil {
+				return nil, fmt.Errorf("author %s: invalid date %q", author.Email, date)
+			}
+			if other, ok := attr.byDate[date]; ok {
+				return nil, fmt.Errorf("date %s is assigned to both %s and %s", date, authors[other].Email, author.Email)
+			}
+			attr.byDate[date] = i
+		}
+		attr.totalWeight += author.Weight
+		attr.authors = append(attr.authors, author)
+	}
+	if committer != nil {
+		c := GitIdentity{Name: strings.TrimSpace(committer.Name), Email: strings.TrimSpace(committer.Email)}
+		if err := validateGitIdentity(c); err != nil {
+			return nil, fmt.Errorf("committer: %w", err)
+		}
+		attr.committer = &c
+	}
+	for i, co := range coAuthors {
+		co = GitIdentity{Name: strings.TrimSpace(co.Name), Email: strings.TrimSpace(co.Email)}
+		if err := validateGitIdentity(co); err != nil {
+			return nil, fmt.Errorf("co-author %d: %w", i+1, err)
+		}
+		attr.coAuthors = append(attr.coAuthors, co)
+	}
+	return attr, nil
+}
+
+// validateGitIdentity 检查身份能否写入提交头。
+func validateGitIdentity(id GitIdentity) error {
+	if id.Name == "" || id.Email == "" {
+		return fmt.Errorf("name and email are required")
+	}
+	if strings.ContainsAny(id.Name, "<>\n") || strings.ContainsAny(id.Email, "<> \n") {
+		return fmt.Errorf("invalid identity %q <%s>", id.Name, id.Email)
+	}
+	if _, err := mail.ParseAddress(id.Email); err != nil {
+		return fmt.Errorf("invalid email %q", id.Email)
+	}
+	return nil
+}
+
+// next 返回某一天下一个提交的作者与提交者。
+// 指定了日期的作者优先，其余提交按权重轮询分配，与多语言比例的分配方式一致。
+func (c *commitAttribution) next(date string) (author, committer GitIdentity) {
+	idx, ok := c.byDate[date]
+	if !ok {
+		position := c.weighted % c.totalWeight
+		c.weighted++
+		for i, a := range c.authors {
+			if position < a.Weight {
+				idx = i
+				break
+			}
+			position -= a.Weight
+		}
+	}
+	chosen := c.authors[idx]
+	author = GitIdentity{Name: chosen.Name, Email: chosen.Email}
+	c.counts[author.Email]++
+	committer = author
+	if c.committer != nil {
+		committer = *c.committer
+	}
+	return author, committer
+}
+
+// message 为提交说明追加 Co-authored-by 尾注，跳过提交作者本人。
+func (c *commitAttribution) message(msg string, author GitIdentity) string {
+	var trailers []string
+	for _, co := range c.coAuthors {
+		if strings.EqualFold(co.Email, author.Email) {
+			continue
+		}
+		trailers = append(trailers, fmt.Sprintf("Co-authored-by: %s <%s>", co.Name, co.Email))
+	}
+	if len(trailers) == 0 {
+		return msg
+	}
+	return msg + "\n\n" + strings.Join(trailers, "\n")
+}
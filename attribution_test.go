@@ -0,0 +1,155 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenerateRepoAttribution(t *testing.T) {
+	a := newTestApp(t)
+	alice := WeightedIdentity{Name: "Alice", Email: "alice@example.com", Weight: 2}
+	bob := WeightedIdentity{Name: "Bob", Email: "bob@example.com"}
+	carol := WeightedIdentity{Name: "Carol", Email: "carol@example.com", Dates: []string{"2024-01-03"}}
+	req := GenerateRepoRequest{
+		Year:           2024,
+		GithubUsername: "tester",
+		GithubEmail:    "tester@example.com",
+		RepoName:       "attribution",
+		Language:       "go",
+		Contributions: []ContributionDay{
+			{Date: "2024-01-02", Count: 3},
+			{Date: "2024-01-03", Count: 2},
+			{Date: "2024-01-04", Count: 3},
+		},
+	}
+	req.Authors = []WeightedIdentity{alice, bob, carol}
+	req.Committer = &GitIdentity{Name: " Paint Bot ", Email: "bot@example.com"}
+	req.CoAuthors = []GitIdentity{{Name: "Alice", Email: "ALICE@example.com"}, {Name: "Dave", Email: "dave@example.com"}}
+
+	resp, err := a.GenerateRepo(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := a.runGitOutput(resp.RepoPath, "log", "--reverse",
+		"--format=%ad|%an|%ae|%cn|%ce|%(trailers:key=Co-authored-by,valueonly,separator=%x2C)", "--date=short", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 指定日期的提交全部归属 Carol 且不占用轮询位置，其余提交按 2:1:1 在 Alice、Bob、Carol 之间轮询；
+	// 提交者统一为 Paint Bot，尾注跳过与作者相同的共同作者（邮箱不区分大小写）
+	const both = "Alice <ALICE@example.com>,Dave <dave@example.com>"
+	const dave = "Dave <dave@example.com>"
+	want := []string{
+		"2024-01-02|Alice|alice@example.com|Paint Bot|bot@example.com|" + dave,
+		"2024-01-02|Alice|alice@example.com|Paint Bot|bot@example.com|" + dave,
+		"2024-01-02|Bob|bob@example.com|Paint Bot|bot@example.com|" + both,
+		"2024-01-03|Carol|carol@example.com|Paint Bot|bot@example.com|" + both,
+		"2024-01-03|Carol|carol@example.com|Paint Bot|bot@example.com|" + both,
+		"2024-01-04|Carol|carol@example.com|Paint Bot|bot@example.com|" + both,
+		"2024-01-04|Alice|alice@example.com|Paint Bot|bot@example.com|" + dave,
+		"2024-01-04|Alice|alice@example.com|Paint Bot|bot@example.com|" + dave,
+	}
+	if got := strings.Split(out, "\n"); !reflect.DeepEqual(got, want) {
+		t.Errorf("commits:\n%s\nwant:\n%s", out, strings.Join(want, "\n"))
+	}
+	wantCounts := map[string]int{"alice@example.com": 4, "bob@example.com": 1, "carol@example.com": 3}
+	if !reflect.DeepEqual(resp.AuthorCommits, wantCounts) {
+		t.Errorf("author commits = %v, want %v", resp.AuthorCommits, wantCounts)
+	}
+}
+
+// 没有指定作者时所有提交归属 GithubUsername/GithubEmail，单独的脚手架提交同样使用覆盖后的提交者。
+func TestGenerateRepoAttributionFallback(t *testing.T) {
+	a := newTestApp(t)
+	req := GenerateRepoRequest{
+		Year:           2024,
+		GithubUsername: "tester",
+		GithubEmail:    "tester@example.com",
+		RepoName:       "attribution-fallback",
+		Language:       "go",
+		Contributions:  []ContributionDay{{Date: "2024-01-02", Count: 2}},
+	}
+	req.Committer = &GitIdentity{Name: "Paint Bot", Email: "bot@example.com"}
+	req.ScaffoldDate = "2023-12-01"
+
+	resp, err := a.GenerateRepo(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := a.runGitOutput(resp.RepoPath, "log", "--reverse", "--format=%ad|%an|%ae|%cn|%ce|%b", "--date=short", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, line := range strings.Split(out, "\n") {
+		if !strings.HasSuffix(line, "|tester|tester@example.com|Paint Bot|bot@example.com|") {
+			t.Errorf("commit %d = %q", i, line)
+		}
+	}
+	if !strings.HasPrefix(out, "2023-12-01|") {
+		t.Errorf("first commit is not the scaffold commit:\n%s", out)
+	}
+	if resp.AuthorCommits["tester@example.com"] != 3 {
+		t.Errorf("author commits = %v, want 3 for tester", resp.AuthorCommits)
+	}
+}
+
+func TestValidateGitIdentity(t *testing.T) {
+	tests := []struct {
+		id   GitIdentity
+		want bool
+	}{
+		{id: GitIdentity{Name: "Alice", Email: "alice@example.com"}, want: true},
+		{id: GitIdentity{Name: "Alice Liddell", Email: "alice+wall@example.com"}, want: true},
+		{id: GitIdentity{Email: "alice@example.com"}},
+		{id: GitIdentity{Name: "Alice"}},
+		{id: GitIdentity{Name: "Alice <a@example.com>", Email: "alice@example.com"}},
+		{id: GitIdentity{Name: "Alice\nBob", Email: "alice@example.com"}},
+		{id: GitIdentity{Name: "Alice", Email: "alice@example.com>"}},
+		{id: GitIdentity{Name: "Alice", Email: "alice @example.com"}},
+		{id: GitIdentity{Name: "Alice", Email: "not-an-email"}},
+	}
+	for _, tt := range tests {
+		if err := validateGitIdentity(tt.id); (err == nil) != tt.want {
+			t.Errorf("validateGitIdentity(%q <%s>) = %v, want valid %v", tt.id.Name, tt.id.Email, err, tt.want)
+		}
+	}
+}
+
+func TestNewCommitAttributionErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		fallback  GitIdentity
+		authors   []WeightedIdentity
+		committer *GitIdentity
+		coAuthors []GitIdentity
+		want      string
+	}{
+		{name: "invalid author", authors: []WeightedIdentity{{Name: "Alice", Email: "alice"}}, want: "author 1"},
+		{name: "negative weight", authors: []WeightedIdentity{{Name: "Alice", Email: "alice@example.com", Weight: -1}}, want: "invalid weight"},
+		{name: "bad date", authors: []WeightedIdentity{{Name: "Alice", Email: "alice@example.com", Dates: []string{"2024-02-30"}}}, want: "invalid date"},
+		{
+			name: "date assigned twice",
+			authors: []WeightedIdentity{
+				{Name: "Alice", Email: "alice@example.com", Dates: []string{"2024-01-02"}},
+				{Name: "Bob", Email: "bob@example.com", Dates: []string{"2024-01-02"}},
+			},
+			want: "assigned to both",
+		},
+		{name: "invalid committer", committer: &GitIdentity{Name: "Bot"}, want: "committer"},
+		{name: "invalid co-author", coAuthors: []GitIdentity{{Name: "Dave <d>", Email: "dave@example.com"}}, want: "co-author 1"},
+		// 没有指定作者时 fallback 即唯一的作者
+		{name: "invalid fallback", fallback: GitIdentity{Name: "tester"}, want: "author 1"},
+	}
+	for _, tt := range tests {
+		fallback := tt.fallback
+		if fallback == (GitIdentity{}) {
+			fallback = GitIdentity{Name: "tester", Email: "tester@example.com"}
+		}
+		_, err := newCommitAttribution(fallback, tt.authors, tt.committer, tt.coAuthors)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error = %v, want it to mention %q", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,428 @@
+package banner
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// 外部字体文件的魔数。
+var (
+	psf1Magic = []byte{0x36, 0x04}
+	psf2Magic = []byte{0x72, 0xb5, 0x4a, 0x86}
+	gzipMagic = []byte{0x1f, 0x8b}
+)
+
+// LoadFontFile 从磁盘读取 BDF 或 PSF（含 .psf.gz）字体，字形高度超过 7 行时按比例缩小。
+// 字体以文件名（去掉扩展名）命名，调用方可通过 Register 使其可供选择。
+func LoadFontFile(path string) (*Font, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read font: %w", err)
+	}
+	name := filepath.Base(path)
+	for ext := filepath.Ext(name); ext != ""; ext = filepath.Ext(name) {
+		name = strings.TrimSuffix(name, ext)
+	}
+	return ParseFont(name, data)
+}
+
+// ParseFont 根据文件内容识别字体格式并解析。
+func ParseFont(name string, data []byte) (*Font, error) {
+	if bytes.HasPrefix(data, gzipMagic) {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("decompress font: %w", err)
+		}
+		if data, err = io.ReadAll(zr); err != nil {
+			return nil, fmt.Errorf("decompress font: %w", err)
+		}
+	}
+
+	var glyphs map[rune][][]bool
+	var err error
+	switch {
+	case bytes.HasPrefix(data, psf2Magic):
+		glyphs, err = parsePSF2(data)
+	case bytes.HasPrefix(data, psf1Magic):
+		glyphs, err = parsePSF1(data)
+	case bytes.HasPrefix(bytes.TrimSpace(data), []byte("STARTFONT")):
+		glyphs, err = parseBDF(data)
+	default:
+		return nil, fmt.Errorf("unrecognised font format (expected BDF or PSF)")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newBitmapFont(name, glyphs)
+}
+
+// newBitmapFont 将解析出的字形统一裁剪、缩放到不超过 7 行，并生成 Font。
+func newBitmapFont(name string, raw map[rune][][]bool) (*Font, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("font %s contains no glyphs", name)
+	}
+
+	// 去掉所有字形共有的上下空白行，避免浪费宝贵的 7 行高度
+	height := 0
+	for _, rows := range raw {
+		height = max(height, len(rows))
+	}
+	top, bottom := height, 0
+	for _, rows := range raw {
+		for y, row := range rows {
+			for _, on := range row {
+				if on {
+					top = min(top, y)
+					bottom = max(bottom, y+1)
+					break
+				}
+			}
+		}
+	}
+	if bottom <= top {
+		return nil, fmt.Errorf("font %s contains only blank glyphs", name)
+	}
+
+	spaceWidth := 0
+	if rows, ok := raw[' ']; ok && len(rows) > 0 {
+		spaceWidth = len(rows[0])
+	}
+	glyphs := make(map[rune]Glyph, len(raw))
+	for r, rows := range raw {
+		width := 0
+		if len(rows) > 0 {
+			width = len(rows[0])
+		}
+		cropped := make([][]bool, bottom-top)
+		for y := range cropped {
+			cropped[y] = make([]bool, width)
+			if top+y < len(rows) {
+				copy(cropped[y], rows[top+y])
+			}
+		}
+		glyphs[r] = Glyph{Rows: downsample(cropped, gridRows)}
+	}
+
+	scaledHeight := len(glyphs[firstRune(glyphs)].Rows)
+	if spaceWidth == 0 {
+		spaceWidth = bottom - top
+	}
+	spaceWidth = max(1, spaceWidth*scaledHeight/(bottom-top))
+
+	font := &Font{Name: name, Height: scaledHeight, SpaceWidth: spaceWidth, Glyphs: glyphs}
+	return font, nil
+}
+
+func firstRune(glyphs map[rune]Glyph) rune {
+	for r := range glyphs {
+		return r
+	}
+	return 0
+}
+
+// downsample 将高度超过 maxHeight 的点阵按比例缩小：每个目标像素覆盖源图的一块区域，
+// 区域内点亮比例不低于 1/3 时视为点亮，以保留细笔画。
+func downsample(rows [][]bool, maxHeight int) [][]bool {
+	h := len(rows)
+	if h <= maxHeight {
+		return rows
+	}
+	w := 0
+	if h > 0 {
+		w = len(rows[0])
+	}
+	outH := maxHeight
+	outW := (w*maxHeight + h/2) / h
+	if w > 0 {
+		outW = max(1, outW)
+	}
+
+	out := make([][]bool, outH)
+	for y := range out {
+		out[y] = make([]bool, outW)
+		y0, y1 := float64(y)*float64(h)/float64(outH), float64(y+1)*float64(h)/float64(outH)
+		for x := range out[y] {
+			x0, x1 := float64(x)*float64(w)/float64(outW), float64(x+1)*float64(w)/float64(outW)
+			var lit, area float64
+			for sy := int(y0); float64(sy) < y1 && sy < h; sy++ {
+				cy := min(y1, float64(sy+1)) - max(y0, float64(sy))
+				for sx := int(x0); float64(sx) < x1 && sx < w; sx++ {
+					cover := cy * (min(x1, float64(sx+1)) - max(x0, float64(sx)))
+					area += cover
+					if rows[sy][sx] {
+						lit += cover
+					}
+				}
+			}
+			out[y][x] = area > 0 && lit/area >= 1.0/3
+		}
+	}
+	return out
+}
+
+// parseBDF 解析 X11 BDF 文本字体，每个字形按 DWIDTH 放置在以字体基线对齐的单元格中。
+func parseBDF(data []byte) (map[rune][][]bool, error) {
+	var ascent, descent int
+	var fontBox [4]int
+	glyphs := make(map[rune][][]bool)
+
+	var (
+		inChar, inBitmap bool
+		encoding         int
+		advance          int
+		bbx              [4]int
+		bitmap           []string
+	)
+	atoi := func(fields []string, line int) ([]int, error) {
+		values := make([]int, len(fields))
+		for i, f := range fields {
+			v, err := strconv.Atoi(f)
+			if err != nil {
+				return nil, fmt.Errorf("bdf line %d: invalid number %q", line, f)
+			}
+			values[i] = v
+		}
+		return values, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if inBitmap && fields[0] != "ENDCHAR" {
+			bitmap = append(bitmap, fields[0])
+			continue
+		}
+		switch fields[0] {
+		case "FONTBOUNDINGBOX":
+			v, err := atoi(fields[1:], line)
+			if err != nil || len(v) != 4 {
+				return nil, fmt.Errorf("bdf line %d: invalid FONTBOUNDINGBOX", line)
+			}
+			copy(fontBox[:], v)
+		case "FONT_ASCENT", "FONT_DESCENT":
+			v, err := atoi(fields[1:], line)
+			if err != nil || len(v) != 1 {
+				return nil, fmt.Errorf("bdf line %d: invalid %s", line, fields[0])
+			}
+			if fields[0] == "FONT_ASCENT" {
+				ascent = v[0]
+			} else {
+				descent = v[0]
+			}
+		case "STARTCHAR":
+			inChar, encoding, advance, bbx, bitmap = true, -1, -1, fontBox, nil
+		case "ENCODING":
+			v, err := atoi(fields[1:], line)
+			if err != nil || len(v) == 0 {
+				return nil, fmt.Errorf("bdf line %d: invalid ENCODING", line)
+			}
+			encoding = v[0]
+		case "DWIDTH":
+			v, err := atoi(fields[1:], line)
+			if err != nil || len(v) == 0 {
+				return nil, fmt.Errorf("bdf line %d: invalid DWIDTH", line)
+			}
+			advance = v[0]
+		case "BBX":
+			v, err := atoi(fields[1:], line)
+			if err != nil || len(v) != 4 {
+				return nil, fmt.Errorf("bdf line %d: invalid BBX", line)
+			}
+			copy(bbx[:], v)
+		case "BITMAP":
+			if !inChar {
+				return nil, fmt.Errorf("bdf line %d: BITMAP outside STARTCHAR", line)
+			}
+			inBitmap = true
+		case "ENDCHAR":
+			if !inChar {
+				return nil, fmt.Errorf("bdf line %d: ENDCHAR outside STARTCHAR", line)
+			}
+			inChar, inBitmap = false, false
+			if encoding < 0 || !utf8.ValidRune(rune(encoding)) {
+				continue
+			}
+			if ascent == 0 && descent == 0 {
+				ascent, descent = fontBox[1]+fontBox[3], -fontBox[3]
+			}
+			if advance < 0 {
+				advance = bbx[0] + max(0, bbx[2])
+			}
+			rows, err := placeBDFGlyph(bitmap, bbx, advance, ascent, descent)
+			if err != nil {
+				return nil, fmt.Errorf("bdf line %d: %w", line, err)
+			}
+			glyphs[rune(encoding)] = rows
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read bdf: %w", err)
+	}
+	if inChar {
+		return nil, fmt.Errorf("bdf: unterminated glyph")
+	}
+	return glyphs, nil
+}
+
+// placeBDFGlyph 将 BBX 描述的位图放到宽为 advance、高为 ascent+descent 的单元格中。
+func placeBDFGlyph(bitmap []string, bbx [4]int, advance, ascent, descent int) ([][]bool, error) {
+	w, h, xoff, yoff := bbx[0], bbx[1], bbx[2], bbx[3]
+	if len(bitmap) != h {
+		return nil, fmt.Errorf("glyph has %d bitmap rows, BBX says %d", len(bitmap), h)
+	}
+	height := ascent + descent
+	width := max(advance, w+max(0, xoff))
+	rows := make([][]bool, height)
+	for y := range rows {
+		rows[y] = make([]bool, width)
+	}
+	top := ascent - (h + yoff)
+	for y, hexRow := range bitmap {
+		bits, err := hex.DecodeString(hexRow)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bitmap row %q", hexRow)
+		}
+		ty := top + y
+		if ty < 0 || ty >= height {
+			continue
+		}
+		for x := 0; x < w && x/8 < len(bits); x++ {
+			tx := x + xoff
+			if tx >= 0 && tx < width && bits[x/8]&(0x80>>(x%8)) != 0 {
+				rows[ty][tx] = true
+			}
+		}
+	}
+	return rows, nil
+}
+
+// parsePSF1 解析 Linux 控制台 PSF1 字体（宽度固定为 8）。
+func parsePSF1(data []byte) (map[rune][][]bool, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("psf1: truncated header")
+	}
+	mode, charSize := data[2], int(data[3])
+	count := 256
+	if mode&0x01 != 0 {
+		count = 512
+	}
+	end := 4 + count*charSize
+	if charSize == 0 || len(data) < end {
+		return nil, fmt.Errorf("psf1: truncated glyph data")
+	}
+	bitmaps := splitPSFGlyphs(data[4:end], count, charSize, 8, charSize)
+
+	if mode&0x06 == 0 {
+		return psfGlyphsByIndex(bitmaps), nil
+	}
+	glyphs := make(map[rune][][]bool)
+	table := data[end:]
+	for i := 0; i < count && len(table) >= 2; i++ {
+		inSequence := false
+		for len(table) >= 2 {
+			v := binary.LittleEndian.Uint16(table)
+			table = table[2:]
+			if v == 0xffff {
+				break
+			}
+			if v == 0xfffe {
+				inSequence = true
+			}
+			if !inSequence {
+				glyphs[rune(v)] = bitmaps[i]
+			}
+		}
+	}
+	return glyphs, nil
+}
+
+// parsePSF2 解析 PSF2 字体。
+func parsePSF2(data []byte) (map[rune][][]bool, error) {
+	if len(data) < 32 {
+		return nil, fmt.Errorf("psf2: truncated header")
+	}
+	le := binary.LittleEndian
+	headerSize := int(le.Uint32(data[8:]))
+	flags := le.Uint32(data[12:])
+	count := int(le.Uint32(data[16:]))
+	charSize := int(le.Uint32(data[20:]))
+	height := int(le.Uint32(data[24:]))
+	width := int(le.Uint32(data[28:]))
+	if width <= 0 || height <= 0 || charSize < height*((width+7)/8) {
+		return nil, fmt.Errorf("psf2: invalid glyph size %dx%d", width, height)
+	}
+	end := headerSize + count*charSize
+	if headerSize < 32 || count <= 0 || end > len(data) {
+		return nil, fmt.Errorf("psf2: truncated glyph data")
+	}
+	bitmaps := splitPSFGlyphs(data[headerSize:end], count, charSize, width, height)
+
+	if flags&0x01 == 0 {
+		return psfGlyphsByIndex(bitmaps), nil
+	}
+	glyphs := make(map[rune][][]bool)
+	table := data[end:]
+	for i := 0; i < count && len(table) > 0; i++ {
+		entry := table
+		if j := bytes.IndexByte(table, 0xff); j >= 0 {
+			entry, table = table[:j], table[j+1:]
+		} else {
+			table = nil
+		}
+		// 0xFE 之后是多码位组合序列，这里只使用单个码位的映射
+		if j := bytes.IndexByte(entry, 0xfe); j >= 0 {
+			entry = entry[:j]
+		}
+		for len(entry) > 0 {
+			r, size := utf8.DecodeRune(entry)
+			if r != utf8.RuneError {
+				glyphs[r] = bitmaps[i]
+			}
+			entry = entry[size:]
+		}
+	}
+	return glyphs, nil
+}
+
+// splitPSFGlyphs 将 PSF 的连续位图数据拆分为逐个字形。
+func splitPSFGlyphs(data []byte, count, charSize, width, height int) [][][]bool {
+	stride := (width + 7) / 8
+	bitmaps := make([][][]bool, count)
+	for i := range bitmaps {
+		glyph := data[i*charSize : (i+1)*charSize]
+		rows := make([][]bool, height)
+		for y := range rows {
+			rows[y] = make([]bool, width)
+			for x := 0; x < width; x++ {
+				rows[y][x] = glyph[y*stride+x/8]&(0x80>>(x%8)) != 0
+			}
+		}
+		bitmaps[i] = rows
+	}
+	return bitmaps
+}
+
+// psfGlyphsByIndex 在没有 Unicode 映射表时，按字形序号对应 Latin-1 码位。
+func psfGlyphsByIndex(bitmaps [][][]bool) map[rune][][]bool {
+	glyphs := make(map[rune][][]bool)
+	for i, rows := range bitmaps {
+		if i > 0xff {
+			break
+		}
+		glyphs[rune(i)] = rows
+	}
+	return glyphs
+}
@@ -0,0 +1,268 @@
+package banner
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+// glyphRows 将字形点阵转换为便于比较的字符串，点亮为 X，空白为 .。
+func glyphRows(g Glyph) []string {
+	rows := make([]string, len(g.Rows))
+	for y, row := range g.Rows {
+		var sb strings.Builder
+		for _, on := range row {
+			if on {
+				sb.WriteByte('X')
+			} else {
+				sb.WriteByte('.')
+			}
+		}
+		rows[y] = sb.String()
+	}
+	return rows
+}
+
+func checkGlyph(t *testing.T, font *Font, r rune, want []string) {
+	t.Helper()
+	g, ok := font.Glyphs[r]
+	if !ok {
+		t.Fatalf("glyph %q missing", r)
+	}
+	got := glyphRows(g)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("glyph %q rows:\n%s\nwant:\n%s", r, strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestLoadFontFileBDF(t *testing.T) {
+	font, err := LoadFontFile("testdata/small.bdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if font.Name != "small" {
+		t.Errorf("name = %q, want small", font.Name)
+	}
+	if font.Height != gridRows {
+		t.Errorf("height = %d, want %d", font.Height, gridRows)
+	}
+	// 空格的 DWIDTH 为 8，随 14 行缩小到 7 行按比例变为 4
+	if font.SpaceWidth != 4 {
+		t.Errorf("space width = %d, want 4", font.SpaceWidth)
+	}
+	if len(font.Glyphs) != 3 {
+		t.Errorf("glyph count = %d, want 3 (ENCODING -1 must be skipped)", len(font.Glyphs))
+	}
+
+	checkGlyph(t, font, 'I', []string{
+		".X..",
+		".X..",
+		".X..",
+		".X..",
+		".X..",
+		".X..",
+		".X..",
+	})
+	checkGlyph(t, font, 'L', []string{
+		"X...",
+		"X...",
+		"X...",
+		"X...",
+		"X...",
+		"X...",
+		"XXXX",
+	})
+}
+
+func TestParseBDFErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "bitmap row count",
+			data: "STARTFONT 2.1\nFONTBOUNDINGBOX 8 2 0 0\nSTARTCHAR A\nENCODING 65\nBBX 8 2 0 0\nBITMAP\nFF\nENDCHAR\nENDFONT\n",
+			want: "BBX says 2",
+		},
+		{
+			name: "unterminated glyph",
+			data: "STARTFONT 2.1\nFONTBOUNDINGBOX 8 1 0 0\nSTARTCHAR A\nENCODING 65\nBBX 8 1 0 0\nBITMAP\nFF\n",
+			want: "unterminated glyph",
+		},
+		{
+			name: "invalid number",
+			data: "STARTFONT 2.1\nFONTBOUNDINGBOX 8 x 0 0\n",
+			want: "invalid FONTBOUNDINGBOX",
+		},
+		{
+			name: "invalid hex",
+			data: "STARTFONT 2.1\nFONTBOUNDINGBOX 8 1 0 0\nSTARTCHAR A\nENCODING 65\nBBX 8 1 0 0\nBITMAP\nZZ\nENDCHAR\nENDFONT\n",
+			want: "invalid bitmap row",
+		},
+		{
+			name: "no glyphs",
+			data: "STARTFONT 2.1\nFONTBOUNDINGBOX 8 1 0 0\nENDFONT\n",
+			want: "contains no glyphs",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFont("bad", []byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// buildPSF1 构造 256 个字形、每个字形 charSize 行的 PSF1 字体，glyphs 按序号给出非空字形的位图。
+func buildPSF1(mode byte, charSize int, glyphs map[int][]byte, table []uint16) []byte {
+	data := []byte{psf1Magic[0], psf1Magic[1], mode, byte(charSize)}
+	bitmaps := make([]byte, 256*charSize)
+	for i, rows := range glyphs {
+		copy(bitmaps[i*charSize:], rows)
+	}
+	data = append(data, bitmaps...)
+	for _, v := range table {
+		data = binary.LittleEndian.AppendUint16(data, v)
+	}
+	return data
+}
+
+func TestParsePSF1(t *testing.T) {
+	// 8 行的字形上下各有空白行，裁剪后只剩 5 行，不需要缩小
+	data := buildPSF1(0, 8, map[int][]byte{
+		'A': {0x00, 0xf0, 0x90, 0xf0, 0x90, 0x90, 0x00, 0x00},
+	}, nil)
+	font, err := ParseFont("console", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if font.Height != 5 {
+		t.Errorf("height = %d, want 5", font.Height)
+	}
+	if len(font.Glyphs) != 256 {
+		t.Errorf("glyph count = %d, want 256", len(font.Glyphs))
+	}
+	checkGlyph(t, font, 'A', []string{
+		"XXXX....",
+		"X..X....",
+		"XXXX....",
+		"X..X....",
+		"X..X....",
+	})
+}
+
+func TestParsePSF1UnicodeTable(t *testing.T) {
+	// 每个字形的映射以 0xFFFF 结束，0xFFFE 之后的组合序列不单独映射
+	table := make([]uint16, 0, 260)
+	table = append(table, 0x263a, 0xfffe, 'x', 0xffff)
+	for i := 1; i < 256; i++ {
+		table = append(table, 0xffff)
+	}
+	data := buildPSF1(0x02, 4, map[int][]byte{0: {0x00, 0x60, 0x60, 0x00}}, table)
+	font, err := ParseFont("console", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(font.Glyphs) != 1 {
+		t.Errorf("glyph count = %d, want 1", len(font.Glyphs))
+	}
+	checkGlyph(t, font, 0x263a, []string{".XX.....", ".XX....."})
+}
+
+// buildPSF2 构造带 Unicode 映射表的 PSF2 字体。
+func buildPSF2(width, height int, bitmaps [][]byte, table []byte) []byte {
+	stride := (width + 7) / 8
+	charSize := stride * height
+	le := binary.LittleEndian
+	data := append([]byte{}, psf2Magic...)
+	for _, v := range []int{0, 32, 1, len(bitmaps), charSize, height, width} {
+		data = le.AppendUint32(data, uint32(v))
+	}
+	for _, rows := range bitmaps {
+		glyph := make([]byte, charSize)
+		copy(glyph, rows)
+		data = append(data, glyph...)
+	}
+	return append(data, table...)
+}
+
+func TestParsePSF2(t *testing.T) {
+	// 宽 10 列的字形每行占两个字节
+	bitmaps := [][]byte{
+		{},
+		{
+			0xc0, 0x40,
+			0x3f, 0x00,
+			0xc0, 0x40,
+		},
+	}
+	var table []byte
+	table = append(table, 'a', 0xff)
+	table = append(table, []byte("é")...)
+	table = append(table, 0xfe)
+	table = append(table, []byte("é")...)
+	table = append(table, 0xff)
+	font, err := ParseFont("terminus", buildPSF2(10, 3, bitmaps, table))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := font.Glyphs['e']; ok {
+		t.Error("combining sequence must not map a single code point")
+	}
+	checkGlyph(t, font, 'é', []string{
+		"XX.......X",
+		"..XXXXXX..",
+		"XX.......X",
+	})
+	checkGlyph(t, font, 'a', []string{"..........", "..........", ".........."})
+}
+
+func TestParsePSFGzip(t *testing.T) {
+	data := buildPSF1(0, 8, map[int][]byte{'A': {0xff, 0x81, 0x81, 0x81, 0x81, 0x81, 0x81, 0xff}}, nil)
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	font, err := ParseFont("console", buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 8 行缩小到 7 行，宽度按比例变为 7
+	if font.Height != gridRows || font.Glyphs['A'].Width() != 7 {
+		t.Fatalf("glyph size = %dx%d, want 7x%d", font.Glyphs['A'].Width(), font.Height, gridRows)
+	}
+}
+
+func TestParsePSFErrors(t *testing.T) {
+	psf2Header := buildPSF2(8, 8, [][]byte{{0xff}}, nil)
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{name: "psf1 truncated header", data: []byte{psf1Magic[0], psf1Magic[1], 0x00}, want: "psf1: truncated header"},
+		{name: "psf1 truncated glyphs", data: buildPSF1(0, 8, nil, nil)[:100], want: "psf1: truncated glyph data"},
+		{name: "psf1 zero char size", data: buildPSF1(0, 0, nil, nil), want: "psf1: truncated glyph data"},
+		{name: "psf2 truncated header", data: psf2Header[:20], want: "psf2: truncated header"},
+		{name: "psf2 truncated glyphs", data: psf2Header[:35], want: "psf2: truncated glyph data"},
+		{name: "psf2 zero width", data: buildPSF2(0, 8, nil, nil), want: "psf2: invalid glyph size"},
+		{name: "unknown format", data: []byte("not a font"), want: "unrecognised font format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFont("bad", tt.data)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
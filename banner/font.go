@@ -0,0 +1,132 @@
+// banner 包将文本排版为贡献图上的点阵，供命令行与后端生成流程使用。
+package banner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Glyph 是单个字符的点阵，Rows 自上而下排列，每行长度即字形宽度。
+type Glyph struct {
+	Rows [][]bool
+}
+
+// Width 返回字形宽度（列数）。
+func (g Glyph) Width() int {
+	if len(g.Rows) == 0 {
+		return 0
+	}
+	return len(g.Rows[0])
+}
+
+// inkBounds 返回字形中包含像素的首列与末列（不含），空白字形返回 (0, 0)。
+func (g Glyph) inkBounds() (int, int) {
+	left, right := g.Width(), 0
+	for _, row := range g.Rows {
+		for x, on := range row {
+			if on {
+				left = min(left, x)
+				right = max(right, x+1)
+			}
+		}
+	}
+	if right == 0 {
+		return 0, 0
+	}
+	return left, right
+}
+
+// Font 是一套点阵字体。
+type Font struct {
+	Name       string         // 字体名称，用于选择字体
+	Height     int            // 字形高度（行数），不超过 7
+	SpaceWidth int            // 空格宽度（列数）
+	Glyphs     map[rune]Glyph // 字符到字形的映射
+}
+
+// Glyph 查找字符对应的字形，找不到时依次尝试大写与小写形式。
+func (f *Font) Glyph(r rune) (Glyph, bool) {
+	if g, ok := f.Glyphs[r]; ok {
+		return g, true
+	}
+	for _, alt := range []rune{unicode.ToUpper(r), unicode.ToLower(r)} {
+		if g, ok := f.Glyphs[alt]; ok {
+			return g, true
+		}
+	}
+	return Glyph{}, false
+}
+
+// parseGlyphs 将以 '#' 表示像素、'.' 表示空白的字符串表转换为字形。
+func parseGlyphs(table map[rune][]string) map[rune]Glyph {
+	glyphs := make(map[rune]Glyph, len(table))
+	for r, rows := range table {
+		g := Glyph{Rows: make([][]bool, len(rows))}
+		for y, row := range rows {
+			g.Rows[y] = make([]bool, len(row))
+			for x, c := range row {
+				g.Rows[y][x] = c == '#'
+			}
+		}
+		glyphs[r] = g
+	}
+	return glyphs
+}
+
+var (
+	fontsMu sync.RWMutex
+	fonts   = map[string]*Font{}
+)
+
+// Register 注册一套字体，同名字体会被覆盖。
+func Register(f *Font) error {
+	if f == nil || strings.TrimSpace(f.Name) == "" {
+		return fmt.Errorf("font name is required")
+	}
+	if f.Height <= 0 || f.Height > 7 {
+		return fmt.Errorf("font %s: height %d is outside 1-7", f.Name, f.Height)
+	}
+	for r, g := range f.Glyphs {
+		if len(g.Rows) != f.Height {
+			return fmt.Errorf("font %s: glyph %q has %d rows, want %d", f.Name, r, len(g.Rows), f.Height)
+		}
+		for _, row := range g.Rows {
+			if len(row) != g.Width() {
+				return fmt.Errorf("font %s: glyph %q has rows of different widths", f.Name, r)
+			}
+		}
+	}
+	fontsMu.Lock()
+	defer fontsMu.Unlock()
+	fonts[strings.ToLower(f.Name)] = f
+	return nil
+}
+
+// LookupFont 按名称（不区分大小写）查找已注册的字体，名称为空时返回默认的 5x7 字体。
+func LookupFont(name string) (*Font, error) {
+	if strings.TrimSpace(name) == "" {
+		name = DefaultFont
+	}
+	fontsMu.RLock()
+	defer fontsMu.RUnlock()
+	f, ok := fonts[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, fmt.Errorf("unknown font %q", name)
+	}
+	return f, nil
+}
+
+// FontNames 返回所有已注册字体的名称，按字母顺序排列。
+func FontNames() []string {
+	fontsMu.RLock()
+	defer fontsMu.RUnlock()
+	names := make([]string, 0, len(fonts))
+	for _, f := range fonts {
+		names = append(names, f.Name)
+	}
+	sort.Strings(names)
+	return names
+}
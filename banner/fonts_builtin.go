@@ -0,0 +1,157 @@
+package banner
+
+// 内置字体名称。
+const (
+	DefaultFont = "5x7" // 与前端 characterPatterns.ts 相同的 5x7 字体
+	CompactFont = "3x5" // 紧凑的 3x5 字体，只包含大写字母、数字与常用符号
+)
+
+func init() {
+	if err := Register(&Font{Name: DefaultFont, Height: 7, SpaceWidth: 3, Glyphs: parseGlyphs(font5x7)}); err != nil {
+		panic(err)
+	}
+	if err := Register(&Font{Name: CompactFont, Height: 5, SpaceWidth: 2, Glyphs: parseGlyphs(font3x5)}); err != nil {
+		panic(err)
+	}
+}
+
+// font5x7 与 frontend/src/data/characterPatterns.ts 保持一致，另补充了几个常用标点。
+var font5x7 = map[rune][]string{
+	'A':      {".###.", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	'B':      {"####.", "#...#", "#...#", "####.", "#...#", "#...#", "####."},
+	'C':      {".###.", "#...#", "#....", "#....", "#....", "#...#", ".###."},
+	'D':      {"####.", "#...#", "#...#", "#...#", "#...#", "#...#", "####."},
+	'E':      {"#####", "#....", "#....", "####.", "#....", "#....", "#####"},
+	'F':      {"#####", "#....", "#....", "####.", "#....", "#....", "#...."},
+	'G':      {".###.", "#...#", "#....", "#.###", "#...#", "#...#", ".###."},
+	'H':      {"#...#", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	'I':      {"#####", "..#..", "..#..", "..#..", "..#..", "..#..", "#####"},
+	'J':      {"..###", "...#.", "...#.", "...#.", "...#.", "#..#.", ".##.."},
+	'K':      {"#...#", "#..#.", "#.#..", "##...", "#.#..", "#..#.", "#...#"},
+	'L':      {"#....", "#....", "#....", "#....", "#....", "#....", "#####"},
+	'M':      {"#...#", "##.##", "#.#.#", "#...#", "#...#", "#...#", "#...#"},
+	'N':      {"#...#", "##..#", "#.#.#", "#..##", "#...#", "#...#", "#...#"},
+	'O':      {".###.", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'P':      {"####.", "#...#", "#...#", "####.", "#....", "#....", "#...."},
+	'Q':      {".###.", "#...#", "#...#", "#...#", "#.#.#", "#..#.", ".##.#"},
+	'R':      {"####.", "#...#", "#...#", "####.", "#.#..", "#..#.", "#...#"},
+	'S':      {".###.", "#...#", "#....", ".###.", "....#", "#...#", ".###."},
+	'T':      {"#####", "..#..", "..#..", "..#..", "..#..", "..#..", "..#.."},
+	'U':      {"#...#", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'V':      {"#...#", "#...#", "#...#", "#...#", "#...#", ".#.#.", "..#.."},
+	'W':      {"#...#", "#...#", "#...#", "#...#", "#.#.#", "##.##", "#...#"},
+	'X':      {"#...#", "#...#", ".#.#.", "..#..", ".#.#.", "#...#", "#...#"},
+	'Y':      {"#...#", "#...#", ".#.#.", "..#..", "..#..", "..#..", "..#.."},
+	'Z':      {"#####", "....#", "...#.", "..#..", ".#...", "#....", "#####"},
+	'a':      {".....", ".....", ".###.", "....#", ".####", "#...#", ".####"},
+	'b':      {"#....", "#....", "####.", "#...#", "#...#", "#...#", "####."},
+	'c':      {".....", ".....", ".###.", "#....", "#....", "#....", ".###."},
+	'd':      {"....#", "....#", ".####", "#...#", "#...#", "#...#", ".####"},
+	'e':      {".....", ".....", ".###.", "#...#", "####.", "#....", ".###."},
+	'f':      {"..##.", ".#...", "####.", ".#...", ".#...", ".#...", ".#..."},
+	'g':      {".....", ".....", ".####", "#...#", ".####", "....#", ".###."},
+	'h':      {"#....", "#....", "####.", "#...#", "#...#", "#...#", "#...#"},
+	'i':      {"..#..", ".....", ".##..", "..#..", "..#..", "..#..", ".###."},
+	'j':      {"...#.", ".....", "..##.", "...#.", "...#.", "#..#.", ".##.."},
+	'k':      {"#....", "#....", "#..#.", "#.#..", "##...", "#.#..", "#..#."},
+	'l':      {".##..", "..#..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'm':      {".....", ".....", "##.#.", "#.#.#", "#.#.#", "#.#.#", "#.#.#"},
+	'n':      {".....", ".....", "####.", "#...#", "#...#", "#...#", "#...#"},
+	'o':      {".....", ".....", ".###.", "#...#", "#...#", "#...#", ".###."},
+	'p':      {".....", ".....", "####.", "#...#", "#...#", "####.", "#...."},
+	'q':      {".....", ".....", ".####", "#...#", "#...#", ".####", "....#"},
+	'r':      {".....", ".....", "#.##.", "##..#", "#....", "#....", "#...."},
+	's':      {".....", ".....", ".###.", "#....", ".###.", "....#", "####."},
+	't':      {".#...", ".#...", "####.", ".#...", ".#...", ".#..#", "..##."},
+	'u':      {".....", ".....", "#...#", "#...#", "#...#", "#..##", ".##.#"},
+	'v':      {".....", ".....", "#...#", "#...#", "#...#", ".#.#.", "..#.."},
+	'w':      {".....", ".....", "#...#", "#...#", "#.#.#", "#.#.#", ".#.#."},
+	'x':      {".....", ".....", "#...#", ".#.#.", "..#..", ".#.#.", "#...#"},
+	'y':      {".....", ".....", "#...#", "#...#", ".####", "....#", ".###."},
+	'z':      {".....", ".....", "#####", "...#.", "..#..", ".#...", "#####"},
+	'0':      {".###.", "#...#", "#..##", "#.#.#", "##..#", "#...#", ".###."},
+	'1':      {"..#..", ".##..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'2':      {".###.", "#...#", "....#", "...#.", "..#..", ".#...", "#####"},
+	'3':      {".###.", "#...#", "....#", "..##.", "....#", "#...#", ".###."},
+	'4':      {"...#.", "..##.", ".#.#.", "#..#.", "#####", "...#.", "...#."},
+	'5':      {"#####", "#....", "####.", "....#", "....#", "#...#", ".###."},
+	'6':      {"..##.", ".#...", "#....", "####.", "#...#", "#...#", ".###."},
+	'7':      {"#####", "....#", "...#.", "..#..", ".#...", ".#...", ".#..."},
+	'8':      {".###.", "#...#", "#...#", ".###.", "#...#", "#...#", ".###."},
+	'9':      {".###.", "#...#", "#...#", ".####", "....#", "...#.", ".##.."},
+	'!':      {"..#..", "..#..", "..#..", "..#..", "..#..", ".....", "..#.."},
+	'@':      {".###.", "#...#", "#.###", "#.#.#", "#.###", "#....", ".###."},
+	'#':      {".#.#.", ".#.#.", "#####", ".#.#.", "#####", ".#.#.", ".#.#."},
+	'$':      {"..#..", ".####", "#.#..", ".###.", "..#.#", "####.", "..#.."},
+	'%':      {"##..#", "##.#.", "..#..", ".#...", "#..##", "..###", "....."},
+	'^':      {"..#..", ".#.#.", "#...#", ".....", ".....", ".....", "....."},
+	'&':      {".##..", "#..#.", "#..#.", ".##..", "#..#.", "#...#", ".####"},
+	'*':      {".....", ".#.#.", "..#..", "#####", "..#..", ".#.#.", "....."},
+	'(':      {"..#..", ".#...", "#....", "#....", "#....", ".#...", "..#.."},
+	')':      {"..#..", "...#.", "....#", "....#", "....#", "...#.", "..#.."},
+	'-':      {".....", ".....", ".....", "#####", ".....", ".....", "....."},
+	'+':      {".....", "..#..", "..#..", "#####", "..#..", "..#..", "....."},
+	'=':      {".....", ".....", "#####", ".....", "#####", ".....", "....."},
+	'_':      {".....", ".....", ".....", ".....", ".....", ".....", "#####"},
+	'\u2764': {".#.#.", "#####", "#####", "#####", ".###.", "..#..", "....."},
+	'.':      {".....", ".....", ".....", ".....", ".....", ".....", "..#.."},
+	',':      {".....", ".....", ".....", ".....", ".....", "..#..", ".#..."},
+	':':      {".....", "..#..", ".....", ".....", ".....", "..#..", "....."},
+	'\'':     {"..#..", "..#..", ".....", ".....", ".....", ".....", "....."},
+	'?':      {".###.", "#...#", "....#", "...#.", "..#..", ".....", "..#.."},
+	'/':      {"....#", "....#", "...#.", "..#..", ".#...", "#....", "#...."},
+}
+
+// font3x5 是紧凑字体，小写字母会回退到对应的大写字形。
+var font3x5 = map[rune][]string{
+	'A':      {".#.", "#.#", "###", "#.#", "#.#"},
+	'B':      {"##.", "#.#", "##.", "#.#", "##."},
+	'C':      {".##", "#..", "#..", "#..", ".##"},
+	'D':      {"##.", "#.#", "#.#", "#.#", "##."},
+	'E':      {"###", "#..", "##.", "#..", "###"},
+	'F':      {"###", "#..", "##.", "#..", "#.."},
+	'G':      {".##", "#..", "#.#", "#.#", ".##"},
+	'H':      {"#.#", "#.#", "###", "#.#", "#.#"},
+	'I':      {"###", ".#.", ".#.", ".#.", "###"},
+	'J':      {"..#", "..#", "..#", "#.#", ".#."},
+	'K':      {"#.#", "#.#", "##.", "#.#", "#.#"},
+	'L':      {"#..", "#..", "#..", "#..", "###"},
+	'M':      {"#.#", "###", "###", "#.#", "#.#"},
+	'N':      {"##.", "#.#", "#.#", "#.#", "#.#"},
+	'O':      {".#.", "#.#", "#.#", "#.#", ".#."},
+	'P':      {"##.", "#.#", "##.", "#..", "#.."},
+	'Q':      {".#.", "#.#", "#.#", "##.", ".##"},
+	'R':      {"##.", "#.#", "##.", "#.#", "#.#"},
+	'S':      {".##", "#..", ".#.", "..#", "##."},
+	'T':      {"###", ".#.", ".#.", ".#.", ".#."},
+	'U':      {"#.#", "#.#", "#.#", "#.#", "###"},
+	'V':      {"#.#", "#.#", "#.#", "#.#", ".#."},
+	'W':      {"#.#", "#.#", "###", "###", "#.#"},
+	'X':      {"#.#", "#.#", ".#.", "#.#", "#.#"},
+	'Y':      {"#.#", "#.#", ".#.", ".#.", ".#."},
+	'Z':      {"###", "..#", ".#.", "#..", "###"},
+	'0':      {"###", "#.#", "#.#", "#.#", "###"},
+	'1':      {".#.", "##.", ".#.", ".#.", "###"},
+	'2':      {"##.", "..#", ".#.", "#..", "###"},
+	'3':      {"##.", "..#", ".#.", "..#", "##."},
+	'4':      {"#.#", "#.#", "###", "..#", "..#"},
+	'5':      {"###", "#..", "##.", "..#", "##."},
+	'6':      {".##", "#..", "###", "#.#", "###"},
+	'7':      {"###", "..#", ".#.", ".#.", ".#."},
+	'8':      {"###", "#.#", "###", "#.#", "###"},
+	'9':      {"###", "#.#", "###", "..#", "##."},
+	'!':      {".#.", ".#.", ".#.", "...", ".#."},
+	'?':      {"##.", "..#", ".#.", "...", ".#."},
+	'.':      {"...", "...", "...", "...", ".#."},
+	',':      {"...", "...", "...", ".#.", "#.."},
+	':':      {"...", ".#.", "...", ".#.", "..."},
+	'\'':     {".#.", ".#.", "...", "...", "..."},
+	'-':      {"...", "...", "###", "...", "..."},
+	'+':      {"...", ".#.", "###", ".#.", "..."},
+	'=':      {"...", "###", "...", "###", "..."},
+	'_':      {"...", "...", "...", "...", "###"},
+	'/':      {"..#", "..#", ".#.", "#..", "#.."},
+	'(':      {".#.", "#..", "#..", "#..", ".#."},
+	')':      {".#.", "..#", "..#", "..#", ".#."},
+	'\u2764': {"#.#", "###", "###", ".#.", "..."},
+}
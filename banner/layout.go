@@ -0,0 +1,257 @@
+package banner
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Align 是文本在一年的贡献图中的水平对齐方式。
+type Align string
+
+const (
+	AlignLeft   Align = "left"
+	AlignCenter Align = "center"
+	AlignRight  Align = "right"
+)
+
+// gridRows 是贡献图的行数（一周七天）。
+const gridRows = 7
+
+// Options 控制文本排版。
+type Options struct {
+	Font     string // 字体名称，默认 5x7
+	Spacing  int    // 在默认 1 列字间距基础上增加的列数，-1 表示字符紧贴
+	Kerning  bool   // 按字形实际墨迹宽度排版，去除字形两侧的空白列
+	Align    Align  // 对齐方式，默认居中
+	Level    int    // 文字像素的色阶 (1-4)，默认 4
+	Scroll   bool   // 文本在一年内放不下时顺延到之后的年份
+	MaxYears int    // 滚动时最多使用的年份数，0 表示不限制
+}
+
+// Day 是排版结果中被点亮的一个格子。
+type Day struct {
+	Date  string // 日期 (YYYY-MM-DD)
+	Level int    // 色阶 (1-4)
+}
+
+// Page 是文本在某一年中的排版结果。
+type Page struct {
+	Year  int    // 年份
+	Text  string // 本年显示的文本片段
+	Width int    // 文本占用的列数
+	Days  []Day  // 按日期升序排列的点亮格子
+}
+
+// cell 是参与排版的一个字符。
+type cell struct {
+	r     rune
+	glyph Glyph
+	space bool
+	width int
+}
+
+// Layout 将文本排版到 year 年的贡献图上。文本过宽时，若启用 Scroll 则在字符边界（优先在空格处）
+// 断开并顺延到后续年份，否则返回错误。
+func Layout(text string, year int, opts Options) ([]Page, error) {
+	if year <= 0 {
+		return nil, fmt.Errorf("invalid year: %d", year)
+	}
+	font, err := LookupFont(opts.Font)
+	if err != nil {
+		return nil, err
+	}
+	level := opts.Level
+	if level == 0 {
+		level = 4
+	}
+	if level < 1 || level > 4 {
+		return nil, fmt.Errorf("level %d is outside 1-4", level)
+	}
+	align := Align(strings.ToLower(string(opts.Align)))
+	switch align {
+	case "":
+		align = AlignCenter
+	case AlignLeft, AlignCenter, AlignRight:
+	default:
+		return nil, fmt.Errorf("unsupported alignment %q", opts.Align)
+	}
+	if opts.Spacing < -1 {
+		return nil, fmt.Errorf("spacing %d would overlap glyphs", opts.Spacing)
+	}
+	if opts.MaxYears < 0 {
+		return nil, fmt.Errorf("invalid max years: %d", opts.MaxYears)
+	}
+	gap := 1 + opts.Spacing
+
+	cells, err := shapeText(font, text, opts.Kerning)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, avail := UsableColumns(year); !opts.Scroll && runWidth(cells, gap) > avail {
+		return nil, fmt.Errorf("text is %d columns wide but year %d only fits %d; enable scrolling or use a smaller font",
+			runWidth(cells, gap), year, avail)
+	}
+
+	var lines [][]cell
+	var cur []cell
+	for i := 0; i < len(cells); {
+		c := cells[i]
+		if len(cur) == 0 && c.space {
+			i++
+			continue
+		}
+		_, avail := UsableColumns(year + len(lines))
+		if runWidth(append(cur, c), gap) <= avail {
+			cur = append(cur, c)
+			i++
+			continue
+		}
+		if len(cur) == 0 {
+			return nil, fmt.Errorf("glyph %q is wider than a year", c.r)
+		}
+		// 优先在最后一个空格处断开，被移出的字符留到下一年重新排版
+		if k := lastSpace(cur); k > 0 {
+			i -= len(cur) - k - 1
+			cur = cur[:k]
+		}
+		lines = append(lines, trimSpaces(cur))
+		cur = nil
+	}
+	if cur = trimSpaces(cur); len(cur) > 0 {
+		lines = append(lines, cur)
+	}
+	if opts.MaxYears > 0 && len(lines) > opts.MaxYears {
+		return nil, fmt.Errorf("text needs %d years but at most %d are allowed", len(lines), opts.MaxYears)
+	}
+
+	pages := make([]Page, len(lines))
+	for i, line := range lines {
+		pages[i] = renderLine(line, year+i, font.Height, gap, align, level)
+	}
+	return pages, nil
+}
+
+// shapeText 将文本转换为字符序列，并去掉首尾空白。不支持的字符会被汇总到一个错误中。
+func shapeText(font *Font, text string, kerning bool) ([]cell, error) {
+	var cells []cell
+	var unsupported []string
+	for _, r := range strings.TrimSpace(text) {
+		if unicode.IsSpace(r) {
+			cells = append(cells, cell{r: ' ', space: true, width: font.SpaceWidth})
+			continue
+		}
+		g, ok := font.Glyph(r)
+		if !ok {
+			unsupported = append(unsupported, fmt.Sprintf("%q", r))
+			continue
+		}
+		if kerning {
+			if left, right := g.inkBounds(); right > left {
+				trimmed := Glyph{Rows: make([][]bool, len(g.Rows))}
+				for y, row := range g.Rows {
+					trimmed.Rows[y] = row[left:right]
+				}
+				g = trimmed
+			}
+		}
+		cells = append(cells, cell{r: r, glyph: g, width: g.Width()})
+	}
+	if len(unsupported) > 0 {
+		return nil, fmt.Errorf("font %s has no glyph for %s", font.Name, strings.Join(unsupported, ", "))
+	}
+	if len(cells) == 0 {
+		return nil, fmt.Errorf("text is empty")
+	}
+	return cells, nil
+}
+
+// runWidth 计算一串字符排版后的总列数。
+func runWidth(cells []cell, gap int) int {
+	width := 0
+	for i, c := range cells {
+		if i > 0 {
+			width += gap
+		}
+		width += c.width
+	}
+	return width
+}
+
+func lastSpace(cells []cell) int {
+	for i := len(cells) - 1; i >= 0; i-- {
+		if cells[i].space {
+			return i
+		}
+	}
+	return -1
+}
+
+func trimSpaces(cells []cell) []cell {
+	for len(cells) > 0 && cells[len(cells)-1].space {
+		cells = cells[:len(cells)-1]
+	}
+	for len(cells) > 0 && cells[0].space {
+		cells = cells[1:]
+	}
+	return cells
+}
+
+// UsableColumns 返回某年贡献图中完整（七天都属于该年）的首列索引与完整列数，
+// 首尾不完整的周会截断图案，因此不用于排版。
+func UsableColumns(year int) (first, width int) {
+	offset := int(time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC).Weekday())
+	days := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	last := (days+offset+6)/7 - 1
+	if offset != 0 {
+		first = 1
+	}
+	if (days+offset)%7 != 0 {
+		last--
+	}
+	return first, last - first + 1
+}
+
+// renderLine 将一行字符按对齐方式放到 year 年的网格上，字形在七行中垂直居中。
+func renderLine(line []cell, year, height, gap int, align Align, level int) Page {
+	first, avail := UsableColumns(year)
+	width := runWidth(line, gap)
+	x := first
+	switch align {
+	case AlignCenter:
+		x += (avail - width) / 2
+	case AlignRight:
+		x += avail - width
+	}
+	top := (gridRows - height) / 2
+
+	jan1 := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	offset := int(jan1.Weekday())
+	lit := make(map[int]bool)
+	var text strings.Builder
+	for i, c := range line {
+		if i > 0 {
+			x += gap
+		}
+		text.WriteRune(c.r)
+		for y, row := range c.glyph.Rows {
+			for dx, on := range row {
+				if on {
+					lit[(x+dx)*gridRows+top+y-offset] = true
+				}
+			}
+		}
+		x += c.width
+	}
+
+	page := Page{Year: year, Text: text.String(), Width: width}
+	days := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	for day := 0; day < days; day++ {
+		if lit[day] {
+			page.Days = append(page.Days, Day{Date: jan1.AddDate(0, 0, day).Format("2006-01-02"), Level: level})
+		}
+	}
+	return page
+}
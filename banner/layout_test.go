@@ -0,0 +1,234 @@
+package banner
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// registerLayoutFonts 注册排版测试使用的字体：1 是一列满高的竖线，2 是两侧留白的竖线，
+// M 宽 20 列，W 比一年的网格更宽；layout-short 只有三行高。
+func registerLayoutFonts(t *testing.T) {
+	t.Helper()
+	bar := func(row string, height int) []string {
+		rows := make([]string, height)
+		for i := range rows {
+			rows[i] = row
+		}
+		return rows
+	}
+	fonts := []*Font{
+		{Name: "layout-test", Height: 7, SpaceWidth: 3, Glyphs: parseGlyphs(map[rune][]string{
+			'1': bar("#", 7),
+			'2': bar(".#.", 7),
+			'M': bar(strings.Repeat("#", 20), 7),
+			'W': bar(strings.Repeat("#", 60), 7),
+		})},
+		{Name: "layout-short", Height: 3, SpaceWidth: 1, Glyphs: parseGlyphs(map[rune][]string{
+			'1': bar("#", 3),
+		})},
+	}
+	for _, f := range fonts {
+		if err := Register(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// litColumns 返回页面中被点亮格子所在的网格列（按升序去重）与行（按升序去重）。
+func litColumns(t *testing.T, p Page) (cols, rows []int) {
+	t.Helper()
+	offset := int(time.Date(p.Year, time.January, 1, 0, 0, 0, 0, time.UTC).Weekday())
+	seenCol, seenRow := map[int]bool{}, map[int]bool{}
+	for _, d := range p.Days {
+		date, err := time.Parse("2006-01-02", d.Date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		i := date.YearDay() - 1 + offset
+		if !seenCol[i/gridRows] {
+			seenCol[i/gridRows] = true
+			cols = append(cols, i/gridRows)
+		}
+		if !seenRow[i%gridRows] {
+			seenRow[i%gridRows] = true
+			rows = append(rows, i%gridRows)
+		}
+	}
+	sort.Ints(cols)
+	sort.Ints(rows)
+	return cols, rows
+}
+
+func TestUsableColumns(t *testing.T) {
+	tests := []struct {
+		year, first, width int
+	}{
+		{year: 2023, first: 0, width: 52}, // 1 月 1 日是周日，最后一周不完整
+		{year: 2024, first: 1, width: 51}, // 闰年，首尾两周都不完整
+	}
+	for _, tt := range tests {
+		if first, width := UsableColumns(tt.year); first != tt.first || width != tt.width {
+			t.Errorf("UsableColumns(%d) = %d, %d; want %d, %d", tt.year, first, width, tt.first, tt.width)
+		}
+	}
+}
+
+func TestLayoutAlignment(t *testing.T) {
+	registerLayoutFonts(t)
+	tests := []struct {
+		year  int
+		align Align
+		want  int
+	}{
+		{year: 2023, align: AlignLeft, want: 0},
+		{year: 2023, align: "", want: 25},
+		{year: 2023, align: "Center", want: 25},
+		{year: 2023, align: AlignRight, want: 51},
+		{year: 2024, align: AlignLeft, want: 1},
+		{year: 2024, align: AlignCenter, want: 26},
+		{year: 2024, align: AlignRight, want: 51},
+	}
+	for _, tt := range tests {
+		pages, err := Layout("1", tt.year, Options{Font: "layout-test", Align: tt.align})
+		if err != nil {
+			t.Fatalf("%d %q: %v", tt.year, tt.align, err)
+		}
+		if len(pages) != 1 || pages[0].Year != tt.year || pages[0].Width != 1 {
+			t.Fatalf("%d %q: pages = %+v", tt.year, tt.align, pages)
+		}
+		cols, rows := litColumns(t, pages[0])
+		if !reflect.DeepEqual(cols, []int{tt.want}) || len(rows) != gridRows {
+			t.Errorf("%d %q: columns %v rows %v, want column %d on all rows", tt.year, tt.align, cols, rows, tt.want)
+		}
+		for _, d := range pages[0].Days {
+			if d.Level != 4 {
+				t.Errorf("%d %q: %s has level %d, want the default 4", tt.year, tt.align, d.Date, d.Level)
+			}
+		}
+	}
+}
+
+func TestLayoutKerningAndSpacing(t *testing.T) {
+	registerLayoutFonts(t)
+	tests := []struct {
+		name      string
+		text      string
+		opts      Options
+		wantCols  []int
+		wantWidth int
+	}{
+		{name: "glyph padding kept", text: "22", wantCols: []int{1, 5}, wantWidth: 7},
+		{name: "kerning trims padding", text: "22", opts: Options{Kerning: true}, wantCols: []int{0, 2}, wantWidth: 3},
+		{name: "default spacing", text: "11", wantCols: []int{0, 2}, wantWidth: 3},
+		{name: "touching glyphs", text: "11", opts: Options{Spacing: -1}, wantCols: []int{0, 1}, wantWidth: 2},
+		{name: "extra spacing", text: "11", opts: Options{Spacing: 2}, wantCols: []int{0, 4}, wantWidth: 5},
+		{name: "space width", text: "1 1", wantCols: []int{0, 6}, wantWidth: 7},
+	}
+	for _, tt := range tests {
+		opts := tt.opts
+		opts.Font, opts.Align = "layout-test", AlignLeft
+		pages, err := Layout(tt.text, 2023, opts)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		cols, _ := litColumns(t, pages[0])
+		if !reflect.DeepEqual(cols, tt.wantCols) || pages[0].Width != tt.wantWidth {
+			t.Errorf("%s: columns %v width %d, want %v width %d", tt.name, cols, pages[0].Width, tt.wantCols, tt.wantWidth)
+		}
+	}
+}
+
+// 矮于七行的字体在七行中垂直居中，并使用指定色阶。
+func TestLayoutVerticalCenterAndLevel(t *testing.T) {
+	registerLayoutFonts(t)
+	pages, err := Layout("1", 2023, Options{Font: "layout-short", Level: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, rows := litColumns(t, pages[0]); !reflect.DeepEqual(rows, []int{2, 3, 4}) {
+		t.Errorf("rows = %v, want [2 3 4]", rows)
+	}
+	for _, d := range pages[0].Days {
+		if d.Level != 2 {
+			t.Errorf("%s has level %d, want 2", d.Date, d.Level)
+		}
+	}
+}
+
+func TestLayoutLineBreaking(t *testing.T) {
+	registerLayoutFonts(t)
+	tests := []struct {
+		name     string
+		text     string
+		maxYears int
+		want     []string
+	}{
+		{name: "break at space", text: "MM MM", want: []string{"MM", "MM"}},
+		{name: "spaces are trimmed", text: "  MM   MM ", want: []string{"MM", "MM"}},
+		{name: "break inside a word", text: "MMM", want: []string{"MM", "M"}},
+		{name: "last space wins", text: "M M MM", want: []string{"M M", "MM"}},
+		{name: "within max years", text: "MM MM", maxYears: 2, want: []string{"MM", "MM"}},
+		{name: "fits in one year", text: "MM", maxYears: 1, want: []string{"MM"}},
+	}
+	for _, tt := range tests {
+		pages, err := Layout(tt.text, 2023, Options{Font: "layout-test", Align: AlignLeft, Scroll: true, MaxYears: tt.maxYears})
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		var texts []string
+		for i, p := range pages {
+			texts = append(texts, p.Text)
+			if p.Year != 2023+i {
+				t.Errorf("%s: page %d year = %d, want %d", tt.name, i, p.Year, 2023+i)
+			}
+			first, avail := UsableColumns(p.Year)
+			if p.Width > avail {
+				t.Errorf("%s: page %d is %d columns wide, year %d fits %d", tt.name, i, p.Width, p.Year, avail)
+			}
+			if cols, _ := litColumns(t, p); len(cols) == 0 || cols[0] != first {
+				t.Errorf("%s: page %d lights columns %v, want them to start at %d", tt.name, i, cols, first)
+			}
+		}
+		if !reflect.DeepEqual(texts, tt.want) {
+			t.Errorf("%s: pages %q, want %q", tt.name, texts, tt.want)
+		}
+	}
+}
+
+func TestLayoutErrors(t *testing.T) {
+	registerLayoutFonts(t)
+	tests := []struct {
+		name string
+		text string
+		year int
+		opts Options
+		want string
+	}{
+		{name: "too wide without scroll", text: "MMM", opts: Options{}, want: "enable scrolling"},
+		{name: "more years than allowed", text: "MM MM M", opts: Options{Scroll: true, MaxYears: 1}, want: "at most 1"},
+		{name: "glyph wider than a year", text: "W", opts: Options{Scroll: true}, want: "wider than a year"},
+		{name: "negative max years", text: "1", opts: Options{MaxYears: -1}, want: "max years"},
+		{name: "overlapping spacing", text: "1", opts: Options{Spacing: -2}, want: "overlap"},
+		{name: "bad alignment", text: "1", opts: Options{Align: "justify"}, want: "alignment"},
+		{name: "bad level", text: "1", opts: Options{Level: 5}, want: "level"},
+		{name: "unsupported glyphs", text: "1xy", opts: Options{}, want: "'x', 'y'"},
+		{name: "blank text", text: "  ", opts: Options{}, want: "empty"},
+		{name: "bad year", text: "1", year: -1, opts: Options{}, want: "year"},
+	}
+	for _, tt := range tests {
+		year := tt.year
+		if year == 0 {
+			year = 2023
+		}
+		opts := tt.opts
+		opts.Font = "layout-test"
+		_, err := Layout(tt.text, year, opts)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error = %v, want it to mention %q", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// branch_check.go 在推送前检查目标分支：是否受保护、是否允许强制推送、是否为默认分支，
+// 并支持将推送的分支设为默认分支（只有默认分支上的提交才会计入贡献图）。
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// BranchCheckResult 描述推送目标分支的预检结果。
+type BranchCheckResult struct {
+	RepoName         string   `json:"repoName"`         // 远程仓库 (owner/repo)
+	Branch           string   `json:"branch"`           // 目标分支
+	RepoExists       bool     `json:"repoExists"`       // 仓库是否存在
+	BranchExists     bool     `json:"branchExists"`     // 远程分支是否已存在
+	DefaultBranch    string   `json:"defaultBranch"`    // 仓库的默认分支
+	IsDefault        bool     `json:"isDefault"`        // 目标分支是否为默认分支
+	Protected        bool     `json:"protected"`        // 目标分支是否受保护
+	AllowForcePushes bool     `json:"allowForcePushes"` // 是否允许强制推送
+	CanPush          bool     `json:"canPush"`          // 当前用户是否有写权限
+	CanSetDefault    bool     `json:"canSetDefault"`    // 当前用户是否可以修改默认分支（需要管理员权限）
+	Problems         []string `json:"problems"`         // 会导致推送失败的问题
+	Warnings         []string `json:"warnings"`         // 不影响推送但需要注意的问题
+}
+
+// CheckPushTarget 在推送前检查目标分支的保护规则与默认分支设置。
+func (a *App) CheckPushTarget(repoName, branch string, forcePush bool) (*BranchCheckResult, error) {
+	if a.userInfo == nil || a.userInfo.Token == "" {
+		return nil, fmt.Errorf("未登录")
+	}
+	if branch == "" {
+		branch = "main"
+	}
+	result := &BranchCheckResult{
+		RepoName: a.fullRepoName(repoName),
+		Branch:   branch,
+		Problems: []string{},
+		Warnings: []string{},
+	}
+	LogInfo("推送前检查目标分支", zap.String("repo", result.RepoName), zap.String("branch", branch))
+
+	var repo struct {
+		DefaultBranch string `json:"default_branch"`
+		Permissions   struct {
+			Admin bool `json:"admin"`
+			Push  bool `json:"push"`
+		} `json:"permissions"`
+	}
+	status, err := a.githubAPI("GET", "/repos/"+result.RepoName, nil, &repo)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		// 仓库不存在时将由推送流程创建，新仓库的第一个分支即默认分支
+		result.AllowForcePushes = true
+		result.CanPush = true
+		result.CanSetDefault = true
+		result.IsDefault = true
+		return result, nil
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("获取仓库信息失败: %d", status)
+	}
+	result.RepoExists = true
+	result.DefaultBranch = repo.DefaultBranch
+	result.IsDefault = repo.DefaultBranch == branch
+	result.CanPush = repo.Permissions.Push
+	result.CanSetDefault = repo.Permissions.Admin
+
+	var remoteBranch struct {
+		Protected bool `json:"protected"`
+	}
+	branchPath := fmt.Sprintf("/repos/%s/branches/%s", result.RepoName, url.PathEscape(branch))
+	if status, err = a.githubAPI("GET", branchPath, nil, &remoteBranch); err != nil {
+		return nil, err
+	}
+	switch status {
+	case http.StatusOK:
+		result.BranchExists = true
+		result.Protected = remoteBranch.Protected
+	case http.StatusNotFound:
+	default:
+		return nil, fmt.Errorf("获取分支信息失败: %d", status)
+	}
+
+	result.AllowForcePushes = !result.Protected
+	if result.Protected {
+		var protection struct {
+			AllowForcePushes struct {
+				Enabled bool `json:"enabled"`
+			} `json:"allow_force_pushes"`
+		}
+		// 保护规则详情需要管理员权限，读取不到时按 GitHub 的默认设置（禁止强制推送）处理
+		status, err := a.githubAPI("GET", branchPath+"/protection", nil, &protection)
+		if err != nil {
+			return nil, err
+		}
+		if status == http.StatusOK {
+			result.AllowForcePushes = protection.AllowForcePushes.Enabled
+		} else {
+			LogInfo("无法读取分支保护规则", zap.Int("status", status))
+		}
+	}
+
+	if !result.CanPush {
+		result.Problems = append(result.Problems, "当前账号对该仓库没有写权限")
+	}
+	if forcePush && result.BranchExists && !result.AllowForcePushes {
+		result.Problems = append(result.Problems, fmt.Sprintf("%s 分支受保护，不允许强制推送", branch))
+	} else if result.Protected {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("%s 分支受保护，推送可能因保护规则（如必需的审查或状态检查）被拒绝", branch))
+	}
+	if !result.IsDefault {
+		msg := fmt.Sprintf("%s 不是默认分支（%s），其上的提交不会计入贡献图", branch, repo.DefaultBranch)
+		if result.CanSetDefault {
+			msg += "，可在推送后将其设为默认分支"
+		}
+		result.Warnings = append(result.Warnings, msg)
+	}
+
+	LogInfo("目标分支检查完成",
+		zap.Bool("protected", result.Protected),
+		zap.Bool("allow_force_pushes", result.AllowForcePushes),
+		zap.Bool("is_default", result.IsDefault),
+		zap.Int("problems", len(result.Problems)))
+	return result, nil
+}
+
+// SetDefaultBranch 将仓库的默认分支修改为指定分支，分支必须已存在于远程。
+func (a *App) SetDefaultBranch(repoName, branch string) error {
+	if a.userInfo == nil || a.userInfo.Token == "" {
+		return fmt.Errorf("未登录")
+	}
+	fullName := a.fullRepoName(repoName)
+	LogInfo("设置默认分支", zap.String("repo", fullName), zap.String("branch", branch))
+
+	status, err := a.githubAPI("PATCH", "/repos/"+fullName, map[string]string{"default_branch": branch}, nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		LogError("设置默认分支失败", zap.Int("status", status))
+		return fmt.Errorf("设置默认分支失败: %d", status)
+	}
+	return nil
+}
+
+// fullRepoName 将 "repo" 补全为当前用户名下的 "owner/repo"。
+func (a *App) fullRepoName(repoName string) string {
+	if strings.Contains(repoName, "/") {
+		return repoName
+	}
+	return a.userInfo.Username + "/" + repoName
+}
+
+// githubAPI 调用 GitHub REST API。payload 非空时以 JSON 发送；状态码为 2xx 且 out 非空时解析响应。
+// 非 2xx 的状态码不视为错误，由调用方根据接口语义处理。
+func (a *App) githubAPI(method, path string, payload, out any) (int, error) {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return 0, fmt.Errorf("序列化请求失败: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, githubAPIBase+path, body)
+	if err != nil {
+		return 0, fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+a.userInfo.Token)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("读取响应失败: %w", err)
+	}
+	if resp.StatusCode/100 != 2 {
+		LogInfo("GitHub API 返回非成功状态", zap.String("path", path), zap.Int("status_code", resp.StatusCode))
+		return resp.StatusCode, nil
+	}
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return resp.StatusCode, fmt.Errorf("解析响应失败: %w", err)
+		}
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// apiResponse 是模拟 GitHub API 对某个路径的响应。
+type apiResponse struct {
+	status int
+	body   string
+}
+
+// newGitHubAPI 将 githubAPIBase 指向按路径返回固定响应的本地服务，未列出的路径返回 404。
+// 返回的函数列出目前收到的每个请求（"方法 路径 请求体"）。
+func newGitHubAPI(t *testing.T, routes map[string]apiResponse) func() []string {
+	t.Helper()
+	var mu sync.Mutex
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer gho_secret" {
+			t.Errorf("%s %s: Authorization = %q", r.Method, r.URL.Path, got)
+		}
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, strings.TrimSpace(fmt.Sprintf("%s %s %s", r.Method, r.URL.EscapedPath(), body)))
+		mu.Unlock()
+		resp, ok := routes[r.URL.EscapedPath()]
+		if !ok {
+			resp = apiResponse{status: http.StatusNotFound, body: `{"message": "Not Found"}`}
+		}
+		w.WriteHeader(resp.status)
+		io.WriteString(w, resp.body)
+	}))
+	t.Cleanup(server.Close)
+	old := githubAPIBase
+	githubAPIBase = server.URL
+	t.Cleanup(func() { githubAPIBase = old })
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), requests...)
+	}
+}
+
+// hasMessage 判断 messages 中是否有包含 substr 的消息。
+func hasMessage(messages []string, substr string) bool {
+	for _, m := range messages {
+		if strings.Contains(m, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	adminRepoJSON  = `{"default_branch": "main", "permissions": {"admin": true, "push": true}}`
+	readerRepoJSON = `{"default_branch": "main", "permissions": {"admin": false, "push": false}}`
+)
+
+func TestCheckPushTargetProtectedBranch(t *testing.T) {
+	tests := []struct {
+		name        string
+		protection  apiResponse
+		forcePush   bool
+		wantForce   bool
+		wantProblem bool
+	}{
+		{
+			name:        "force push blocked",
+			protection:  apiResponse{http.StatusOK, `{"allow_force_pushes": {"enabled": false}}`},
+			forcePush:   true,
+			wantProblem: true,
+		},
+		{
+			name:       "force push allowed by the rule",
+			protection: apiResponse{http.StatusOK, `{"allow_force_pushes": {"enabled": true}}`},
+			forcePush:  true,
+			wantForce:  true,
+		},
+		{
+			// 读取不到保护规则时按禁止强制推送处理
+			name:        "protection rule unreadable",
+			protection:  apiResponse{http.StatusForbidden, `{"message": "Must have admin rights"}`},
+			forcePush:   true,
+			wantProblem: true,
+		},
+		{
+			name:       "regular push",
+			protection: apiResponse{http.StatusOK, `{"allow_force_pushes": {"enabled": false}}`},
+		},
+	}
+	for _, tt := range tests {
+		a := newTestApp(t)
+		a.userInfo = &UserInfo{Username: "octo", Token: "gho_secret"}
+		newGitHubAPI(t, map[string]apiResponse{
+			"/repos/octo/wall":                          {http.StatusOK, adminRepoJSON},
+			"/repos/octo/wall/branches/main":            {http.StatusOK, `{"protected": true}`},
+			"/repos/octo/wall/branches/main/protection": tt.protection,
+		})
+
+		result, err := a.CheckPushTarget("wall", "", tt.forcePush)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if !result.RepoExists || !result.BranchExists || !result.Protected || !result.IsDefault || result.Branch != "main" {
+			t.Errorf("%s: result = %+v", tt.name, result)
+		}
+		if result.AllowForcePushes != tt.wantForce {
+			t.Errorf("%s: allow force pushes = %v, want %v", tt.name, result.AllowForcePushes, tt.wantForce)
+		}
+		if got := hasMessage(result.Problems, "不允许强制推送"); got != tt.wantProblem {
+			t.Errorf("%s: problems = %q, want force push problem %v", tt.name, result.Problems, tt.wantProblem)
+		}
+		if !tt.wantProblem && !hasMessage(result.Warnings, "受保护") {
+			t.Errorf("%s: warnings = %q, want a protection warning", tt.name, result.Warnings)
+		}
+	}
+}
+
+// 仓库不存在时由推送流程创建，没有任何问题。
+func TestCheckPushTargetMissingRepo(t *testing.T) {
+	a := newTestApp(t)
+	a.userInfo = &UserInfo{Username: "octo", Token: "gho_secret"}
+	requests := newGitHubAPI(t, nil)
+
+	result, err := a.CheckPushTarget("octo/new-wall", "main", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RepoExists || !result.CanPush || !result.CanSetDefault || !result.IsDefault || !result.AllowForcePushes {
+		t.Errorf("result = %+v", result)
+	}
+	if len(result.Problems) != 0 || len(result.Warnings) != 0 {
+		t.Errorf("problems %q, warnings %q; want none", result.Problems, result.Warnings)
+	}
+	if got := requests(); len(got) != 1 || got[0] != "GET /repos/octo/new-wall" {
+		t.Errorf("requests = %q, want only the repository lookup", got)
+	}
+}
+
+func TestCheckPushTargetWithoutAdminRights(t *testing.T) {
+	a := newTestApp(t)
+	a.userInfo = &UserInfo{Username: "octo", Token: "gho_secret"}
+	requests := newGitHubAPI(t, map[string]apiResponse{
+		"/repos/octo/wall": {http.StatusOK, readerRepoJSON},
+	})
+
+	result, err := a.CheckPushTarget("wall", "feature/x", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.CanPush || result.CanSetDefault || result.IsDefault || result.BranchExists || result.DefaultBranch != "main" {
+		t.Errorf("result = %+v", result)
+	}
+	if !hasMessage(result.Problems, "没有写权限") {
+		t.Errorf("problems = %q, want a missing write permission problem", result.Problems)
+	}
+	if !hasMessage(result.Warnings, "不是默认分支") || hasMessage(result.Warnings, "设为默认分支") {
+		t.Errorf("warnings = %q, want a non-default warning without the set-default hint", result.Warnings)
+	}
+	got := requests()
+	if want := "GET /repos/octo/wall/branches/feature%2Fx"; len(got) != 2 || got[1] != want {
+		t.Errorf("requests = %q, want the escaped branch lookup %q", got, want)
+	}
+}
+
+func TestCheckPushTargetErrors(t *testing.T) {
+	a := newTestApp(t)
+	if _, err := a.CheckPushTarget("wall", "main", false); err == nil {
+		t.Error("logged out: expected error")
+	}
+
+	a.userInfo = &UserInfo{Username: "octo", Token: "gho_secret"}
+	newGitHubAPI(t, map[string]apiResponse{
+		"/repos/octo/wall":               {http.StatusOK, adminRepoJSON},
+		"/repos/octo/wall/branches/main": {http.StatusInternalServerError, `{}`},
+	})
+	if _, err := a.CheckPushTarget("wall", "main", false); err == nil {
+		t.Error("server error: expected error")
+	}
+}
+
+func TestSetDefaultBranch(t *testing.T) {
+	a := newTestApp(t)
+	a.userInfo = &UserInfo{Username: "octo", Token: "gho_secret"}
+	requests := newGitHubAPI(t, map[string]apiResponse{
+		"/repos/octo/wall": {http.StatusOK, adminRepoJSON},
+	})
+	if err := a.SetDefaultBranch("wall", "paint"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := requests(), `PATCH /repos/octo/wall {"default_branch":"paint"}`; len(got) != 1 || got[0] != want {
+		t.Errorf("requests = %q, want %q", got, want)
+	}
+	if err := a.SetDefaultBranch("octo/missing", "paint"); err == nil {
+		t.Error("missing repo: expected error")
+	}
+}
@@ -0,0 +1,266 @@
+// cli.go 提供无界面的命令行入口，便于在脚本或定时任务中直接调用部分后端功能。
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"green-wall/banner"
+)
+
+// cliCommands 注册所有可用的子命令。
+var cliCommands = map[string]func(args []string) error{
+	"drip":   runDripCommand,
+	"plan":   runPlanCommand,
+	"render": runRenderCommand,
+	"text":   runTextCommand,
+}
+
+// runCLI 检查命令行参数，若第一个参数是已注册的子命令则执行它并返回退出码；
+// 否则返回 handled=false，由调用方继续启动图形界面。
+func runCLI(args []string) (handled bool, exitCode int) {
+	if len(args) == 0 {
+		return false, 0
+	}
+	command, ok := cliCommands[args[0]]
+	if !ok {
+		return false, 0
+	}
+	if err := command(args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", args[0], err)
+		return true, 1
+	}
+	return true, 0
+}
+
+// readContributionsFile 读取贡献数据文件，支持 .greenwall 项目文件以及 JSON/CSV/TSV 贡献数据。
+// 对于项目文件，同时返回其中记录的年份。
+func readContributionsFile(path string) ([]ContributionDay, int, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), projectFileExtension) {
+		doc, _, err := decodeProject(data)
+		if err != nil {
+			return nil, 0, err
+		}
+		return doc.Contributions, doc.Year, nil
+	}
+
+	contributions, err := parseContributions(data, detectContributionFormat("", path, data), mergeStrategyLast)
+	if err != nil {
+		return nil, 0, err
+	}
+	return contributions, 0, nil
+}
+
+// writeCLIOutput 将结果写入文件，路径为 "-" 或空时写到标准输出。
+func writeCLIOutput(path string, data []byte) error {
+	if path == "" || path == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// runRenderCommand 实现 `render` 子命令：将贡献数据渲染为 SVG 或 PNG 图片。
+func runRenderCommand(args []string) error {
+	fs := flag.NewFlagSet("render", flag.ContinueOnError)
+	in := fs.String("in", "-", "input file (.greenwall, .json, .csv, .tsv); - for stdin")
+	out := fs.String("out", "-", "output file; - for stdout")
+	format := fs.String("format", "", "image format: svg or png (default: from -out extension, else svg)")
+	theme := fs.String("theme", gridThemeLight, "colour theme: light or dark")
+	shading := fs.String("shading", gridShadingDrawing, "shading: drawing or github")
+	year := fs.Int("year", 0, "year to render (default: inferred from data)")
+	scale := fs.Int("scale", 1, "PNG scale factor")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	contributions, projectYear, err := readContributionsFile(*in)
+	if err != nil {
+		return err
+	}
+	opts := GridRenderOptions{Year: *year, Theme: *theme, Shading: *shading, Scale: *scale}
+	if opts.Year == 0 {
+		opts.Year = projectYear
+	}
+
+	imageFormat := *format
+	if imageFormat == "" {
+		imageFormat = strings.TrimPrefix(strings.ToLower(filepath.Ext(*out)), ".")
+	}
+	data, err := renderContributionImage(contributions, imageFormat, opts)
+	if err != nil {
+		return err
+	}
+	return writeCLIOutput(*out, data)
+}
+
+// runTextCommand 实现 `text` 子命令：将文本排版为贡献数据并以 JSON/CSV/TSV 输出。
+func runTextCommand(args []string) error {
+	fs := flag.NewFlagSet("text", flag.ContinueOnError)
+	req := TextBannerRequest{}
+	fs.StringVar(&req.Text, "text", "", "text to render")
+	fs.IntVar(&req.Year, "year", time.Now().Year(), "first year to render into")
+	fs.StringVar(&req.Font, "font", "", "font name or path to a .bdf/.psf file (default 5x7)")
+	fs.IntVar(&req.Spacing, "spacing", 0, "extra columns between glyphs (-1 packs glyphs together)")
+	fs.BoolVar(&req.Kerning, "kerning", false, "trim blank columns around glyphs")
+	fs.StringVar(&req.Align, "align", "", "alignment: left, center or right (default center)")
+	fs.IntVar(&req.Level, "level", 0, "shade level 1-4 (default 4)")
+	fs.BoolVar(&req.Scroll, "scroll", false, "continue into following years when the text is too wide")
+	fs.IntVar(&req.MaxYears, "max-years", 0, "maximum number of years when scrolling (0 = unlimited)")
+	out := fs.String("out", "-", "output file; - for stdout")
+	format := fs.String("format", "", "output format: json, csv or tsv (default: from -out extension, else json)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if req.Text == "" && fs.NArg() > 0 {
+		req.Text = strings.Join(fs.Args(), " ")
+	}
+	if info, err := os.Stat(req.Font); err == nil && !info.IsDir() {
+		font, err := banner.LoadFontFile(req.Font)
+		if err != nil {
+			return err
+		}
+		if err := banner.Register(font); err != nil {
+			return err
+		}
+		req.Font = font.Name
+	}
+
+	resp, err := renderTextBanner(req)
+	if err != nil {
+		return err
+	}
+	for _, page := range resp.Pages {
+		fmt.Fprintf(os.Stderr, "%d: %s (%d columns)\n", page.Year, page.Text, page.Width)
+	}
+	data, err := encodeContributions(resp.Contributions, detectContributionFormat(*format, *out, nil))
+	if err != nil {
+		return err
+	}
+	return writeCLIOutput(*out, data)
+}
+
+// runPlanCommand 实现 `plan` 子命令：输出生成仓库的完整计划 (JSON)，不创建目录也不修改任何仓库，
+// 便于比较两次生成的差异，例如
+//
+//	GreenWall plan -in art.greenwall -languages go:60,python:40 -seed 42 -out plan.json
+func runPlanCommand(args []string) error {
+	fs := flag.NewFlagSet("plan", flag.ContinueOnError)
+	req := GenerateRepoRequest{}
+	in := fs.String("in", "-", "input file (.greenwall, .json, .csv, .tsv); - for stdin")
+	out := fs.String("out", "-", "output file; - for stdout")
+	fs.IntVar(&req.Year, "year", 0, "year of the contributions (default: inferred from a project file)")
+	fs.StringVar(&req.GithubUsername, "user", "", "commit author name (default: logged-in account or git config)")
+	fs.StringVar(&req.GithubEmail, "email", "", "commit author email (default: logged-in account or git config)")
+	fs.StringVar(&req.RepoName, "repo", "", "repository name")
+	fs.StringVar(&req.Language, "language", "", "single language (default markdown)")
+	languageList := fs.String("languages", "", "language mix, e.g. go:60,python:40")
+	fs.StringVar(&req.FileLayout, "layout", "", "file layout: single, daily, monthly or append")
+	fs.StringVar(&req.ContentMode, "content-mode", "", "content mode: full or append")
+	fs.Int64Var(&req.Seed, "seed", 0, "seed for reproducible commit times (0 = midnight plus one second per commit)")
+	fs.StringVar(&req.ScaffoldDate, "scaffold-date", "", "commit template files separately on this date, before the drawn year (YYYY-MM-DD)")
+	message := fs.String("message", "", "commit message template")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	contributions, projectYear, err := readContributionsFile(*in)
+	if err != nil {
+		return err
+	}
+	req.Contributions = contributions
+	if req.Year == 0 {
+		req.Year = projectYear
+	}
+	if *languageList != "" {
+		req.MultiLanguage = true
+		for _, item := range strings.Split(*languageList, ",") {
+			name, ratio, ok := strings.Cut(strings.TrimSpace(item), ":")
+			config := LanguageConfig{Language: name, Ratio: 100}
+			if ok {
+				if config.Ratio, err = strconv.Atoi(ratio); err != nil {
+					return fmt.Errorf("invalid ratio in %q", item)
+				}
+			}
+			req.LanguageConfigs = append(req.LanguageConfigs, config)
+		}
+	}
+	if *message != "" {
+		req.CommitMessage = &CommitMessageOptions{Template: *message}
+	}
+
+	plan, err := NewApp().planGeneration(req)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeCLIOutput(*out, append(data, '\n'))
+}
+
+// runDripCommand 实现 `drip` 子命令：运行一个或全部滴灌计划，适合由 cron 或 systemd 定时器每天调用，例如
+//
+//	0 9 * * * /usr/local/bin/GreenWall drip
+func runDripCommand(args []string) error {
+	fs := flag.NewFlagSet("drip", flag.ContinueOnError)
+	name := fs.String("plan", "", "plan to run (default: all plans)")
+	list := fs.Bool("list", false, "list plans and their progress instead of running them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	app := NewApp()
+	plans, err := app.ListDripPlans()
+	if err != nil {
+		return err
+	}
+	if *list {
+		for _, plan := range plans {
+			fmt.Printf("%s\t%s\t%d/%d days\tlast run %s\n", plan.Name, plan.RepoName, len(plan.Completed), len(plan.Contributions), plan.LastRunAt)
+		}
+		return nil
+	}
+
+	var failed []string
+	ran := false
+	for i := range plans {
+		if *name != "" && plans[i].Name != sanitiseRepoName(*name) {
+			continue
+		}
+		ran = true
+		result, err := app.runDripPlan(plans[i].Name, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", plans[i].Name, err)
+			failed = append(failed, plans[i].Name)
+			continue
+		}
+		fmt.Printf("%s: %s\n", plans[i].Name, result.Message)
+	}
+	if *name != "" && !ran {
+		return fmt.Errorf("drip plan %q not found", *name)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d plan(s) failed: %s", len(failed), strings.Join(failed, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// commit_message.go 使用 text/template 生成提交说明，支持词表、conventional commit 前缀与多行正文。
+// 模板在创建仓库之前即完成解析与试渲染，错误会在生成开始前报告。
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// defaultCommitMessageTemplate 与早期版本固定的提交说明保持一致。
+const defaultCommitMessageTemplate = "Contribution on {{.Date}} ({{.Index}}/{{.Count}})"
+
+// CommitMessageOptions 定义提交说明模板。
+type CommitMessageOptions struct {
+	Template          string   `json:"template"`          // text/template 模板，为空时使用 "Contribution on {{.Date}} ({{.Index}}/{{.Count}})"
+	Words             []string `json:"words"`             // 词表，模板中通过 .Word 或 word 函数使用
+	ConventionalTypes []string `json:"conventionalTypes"` // 非空时按提交顺序轮换添加前缀，如 feat、fix、docs
+	Scope             string   `json:"scope"`             // conventional commit 的可选作用域
+}
+
+// CommitMessageData 是提交说明模板可访问的数据。
+type CommitMessageData struct {
+	Date     string    // 提交日期 (YYYY-MM-DD)
+	Time     time.Time // 提交时间
+	Weekday  string    // 星期，如 Monday
+	Index    int       // 当天的第几个提交，从 1 开始
+	Count    int       // 当天的提交总数
+	Number   int       // 在全部生成提交中的序号，从 1 开始
+	Language string    // 本次提交使用的语言
+	Word     string    // 按序号从词表中轮换取出的词，词表为空时为空字符串
+	Words    []string  // 完整词表
+}
+
+// commitMessageRenderer 渲染每个提交的说明。
+type commitMessageRenderer struct {
+	tmpl  *template.Template
+	opts  CommitMessageOptions
+	scope string
+}
+
+// newCommitMessageRenderer 解析模板并用示例数据试渲染一次，opts 为 nil 时使用默认模板。
+func newCommitMessageRenderer(opts *CommitMessageOptions) (*commitMessageRenderer, error) {
+	r := &commitMessageRenderer{}
+	if opts != nil {
+		r.opts = *opts
+	}
+	text := r.opts.Template
+	if strings.TrimSpace(text) == "" {
+		text = defaultCommitMessageTemplate
+	}
+	for _, t := range r.opts.ConventionalTypes {
+		if t == "" || strings.ContainsAny(t, " :()\n") {
+			return nil, fmt.Errorf("invalid conventional commit type %q", t)
+		}
+	}
+	if r.opts.Scope = strings.TrimSpace(r.opts.Scope); strings.ContainsAny(r.opts.Scope, " :()\n") {
+		return nil, fmt.Errorf("invalid conventional commit scope %q", r.opts.Scope)
+	}
+
+	words := r.opts.Words
+	funcs := template.FuncMap{
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"word": func(i int) string {
+			if len(words) == 0 {
+				return ""
+			}
+			return words[((i%len(words))+len(words))%len(words)]
+		},
+	}
+	tmpl, err := template.New("commit").Funcs(funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse commit message template: %w", err)
+	}
+	r.tmpl = tmpl
+
+	sampleTime := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	if _, err := r.render(CommitMessageData{
+		Date:     sampleTime.Format("2006-01-02"),
+		Time:     sampleTime,
+		Index:    1,
+		Count:    1,
+		Number:   1,
+		Language: "markdown",
+	}); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// render 渲染一条提交说明：去掉首尾空白行，并按需添加 conventional commit 前缀。
+func (r *commitMessageRenderer) render(data CommitMessageData) (string, error) {
+	data.Words = r.opts.Words
+	if len(r.opts.Words) > 0 {
+		data.Word = r.opts.Words[(data.Number-1)%len(r.opts.Words)]
+	}
+	if data.Weekday == "" {
+		data.Weekday = data.Time.Weekday().String()
+	}
+
+	var buf bytes.Buffer
+	if err := r.tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("render commit message template: %w", err)
+	}
+	msg := strings.TrimSpace(strings.ReplaceAll(buf.String(), "\r\n", "\n"))
+	if msg == "" {
+		return "", fmt.Errorf("commit message template produced an empty message")
+	}
+
+	if len(r.opts.ConventionalTypes) > 0 {
+		prefix := r.opts.ConventionalTypes[(data.Number-1)%len(r.opts.ConventionalTypes)]
+		if r.opts.Scope != "" {
+			prefix += "(" + r.opts.Scope + ")"
+		}
+		msg = prefix + ": " + msg
+	}
+	return msg, nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCommitMessageRender(t *testing.T) {
+	commitTime := time.Date(2024, 3, 8, 14, 30, 0, 0, time.UTC)
+	data := CommitMessageData{Date: "2024-03-08", Time: commitTime, Index: 2, Count: 3, Number: 5, Language: "go"}
+	tests := []struct {
+		name string
+		opts *CommitMessageOptions
+		want string
+	}{
+		{name: "default template", want: "Contribution on 2024-03-08 (2/3)"},
+		{name: "blank template uses the default", opts: &CommitMessageOptions{Template: "  \n"}, want: "Contribution on 2024-03-08 (2/3)"},
+		{
+			name: "all placeholders",
+			opts: &CommitMessageOptions{Template: "{{.Number}} {{.Weekday}} {{.Time.Format \"15:04\"}} {{.Language}} {{.Index}}/{{.Count}}"},
+			want: "5 Friday 14:30 go 2/3",
+		},
+		{
+			// 第 5 个提交轮换回词表的第一个词，word 函数的负数下标从末尾取
+			name: "words",
+			opts: &CommitMessageOptions{Template: "{{.Word}} {{word 0}} {{word -1}} {{len .Words}}", Words: []string{"alpha", "beta"}},
+			want: "alpha alpha beta 2",
+		},
+		{name: "word without a word list", opts: &CommitMessageOptions{Template: "tidy{{.Word}}{{word 3}}"}, want: "tidy"},
+		{name: "functions", opts: &CommitMessageOptions{Template: "{{upper .Language}} {{lower \"README\"}}"}, want: "GO readme"},
+		{
+			name: "surrounding blank lines are trimmed",
+			opts: &CommitMessageOptions{Template: "\r\n\nUpdate {{.Language}}\r\n\r\nBody line\n\n"},
+			want: "Update go\n\nBody line",
+		},
+		{
+			name: "conventional type rotates by number",
+			opts: &CommitMessageOptions{Template: "update", ConventionalTypes: []string{"feat", "fix"}},
+			want: "feat: update",
+		},
+		{
+			name: "conventional scope",
+			opts: &CommitMessageOptions{Template: "update", ConventionalTypes: []string{"docs"}, Scope: " wall "},
+			want: "docs(wall): update",
+		},
+	}
+	for _, tt := range tests {
+		r, err := newCommitMessageRenderer(tt.opts)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		got, err := r.render(data)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// 模板错误在创建渲染器时即被发现，而不是在生成到一半时。
+func TestNewCommitMessageRendererErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts CommitMessageOptions
+		want string
+	}{
+		{name: "syntax error", opts: CommitMessageOptions{Template: "{{.Date"}, want: "parse"},
+		{name: "unknown key", opts: CommitMessageOptions{Template: "{{.Author}}"}, want: "Author"},
+		{name: "unknown function", opts: CommitMessageOptions{Template: "{{title .Date}}"}, want: "title"},
+		{name: "empty result", opts: CommitMessageOptions{Template: "{{if false}}x{{end}}  \n"}, want: "empty message"},
+		{name: "bad conventional type", opts: CommitMessageOptions{ConventionalTypes: []string{"feat:"}}, want: "conventional commit type"},
+		{name: "empty conventional type", opts: CommitMessageOptions{ConventionalTypes: []string{""}}, want: "conventional commit type"},
+		{name: "bad scope", opts: CommitMessageOptions{ConventionalTypes: []string{"feat"}, Scope: "a b"}, want: "conventional commit scope"},
+	}
+	for _, tt := range tests {
+		_, err := newCommitMessageRenderer(&tt.opts)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error = %v, want it to mention %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+// 只对部分提交渲染为空的模板可以通过试渲染，但在渲染到这些提交时报错。
+func TestCommitMessageRenderEmptyForSomeCommits(t *testing.T) {
+	r, err := newCommitMessageRenderer(&CommitMessageOptions{Template: `{{if ne .Language "python"}}Update {{.Language}}{{end}}`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := r.render(CommitMessageData{Date: "2024-01-02", Index: 1, Count: 1, Number: 1, Language: "go"}); err != nil || got != "Update go" {
+		t.Errorf("go commit = %q, %v", got, err)
+	}
+	if _, err := r.render(CommitMessageData{Date: "2024-01-02", Index: 1, Count: 1, Number: 2, Language: "python"}); err == nil {
+		t.Error("python commit: expected an empty message error")
+	}
+}
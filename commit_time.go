@@ -0,0 +1,47 @@
+// commit_time.go 计算生成提交的时间戳。相同的请求与种子总是得到相同的时间，
+// 因此生成的提交 SHA 可以复现。
+package main
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// 使用种子时，提交时间落在当天的这一时间段内 (UTC)。
+const (
+	seededDayStart = 10 * time.Hour
+	seededDayEnd   = 18 * time.Hour
+)
+
+// commitTimes 返回某一天 count 个提交的时间，按先后排序。
+// seed 为 0 时沿用午夜起每个提交间隔 1 秒的时间；非 0 时在白天时段内生成可复现的随机时间，
+// 每天使用由种子与日期派生的独立随机源，修改某一天不会影响其他日期的时间。
+func commitTimes(day time.Time, count int, seed int64) []time.Time {
+	times := make([]time.Time, count)
+	if seed == 0 {
+		for i := range times {
+			times[i] = day.Add(time.Duration(i) * time.Second)
+		}
+		return times
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(day.Format("2006-01-02")))
+	rng := rand.New(rand.NewSource(seed ^ int64(h.Sum64())))
+	window := int64((seededDayEnd - seededDayStart) / time.Second)
+	offsets := make([]int64, count)
+	for i := range offsets {
+		offsets[i] = rng.Int63n(window)
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+	for i := range offsets {
+		// 保证同一天的提交时间严格递增
+		if i > 0 && offsets[i] <= offsets[i-1] {
+			offsets[i] = offsets[i-1] + 1
+		}
+		times[i] = day.Add(seededDayStart + time.Duration(offsets[i])*time.Second)
+	}
+	return times
+}
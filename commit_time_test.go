@@ -0,0 +1,71 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCommitTimes(t *testing.T) {
+	day := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	unseeded := commitTimes(day, 3, 0)
+	for i, got := range unseeded {
+		if want := day.Add(time.Duration(i) * time.Second); !got.Equal(want) {
+			t.Errorf("unseeded commit %d at %s, want %s", i, got, want)
+		}
+	}
+
+	seeded := commitTimes(day, 50, 42)
+	for i, got := range seeded {
+		if got.Before(day.Add(seededDayStart)) || !got.Before(day.Add(seededDayEnd)) {
+			t.Errorf("seeded commit %d at %s is outside the daytime window", i, got)
+		}
+		if i > 0 && !got.After(seeded[i-1]) {
+			t.Errorf("seeded commit %d at %s is not after %s", i, got, seeded[i-1])
+		}
+	}
+	for i, got := range commitTimes(day, 50, 42) {
+		if !got.Equal(seeded[i]) {
+			t.Fatalf("commit %d at %s on the second run, want %s", i, got, seeded[i])
+		}
+	}
+}
+
+// 相同请求与种子生成的仓库 main 分支 SHA 完全相同，种子不同时不同。
+func TestGenerateRepoReproducible(t *testing.T) {
+	a := newTestApp(t)
+	generate := func(seed int64) string {
+		t.Helper()
+		req := GenerateRepoRequest{
+			Year:           2024,
+			GithubUsername: "tester",
+			GithubEmail:    "tester@example.com",
+			RepoName:       "seeded",
+			Language:       "go",
+			Contributions: []ContributionDay{
+				{Date: "2024-01-02", Count: 3},
+				{Date: "2024-06-15", Count: 1},
+			},
+		}
+		req.Seed = seed
+		resp, err := a.GenerateRepo(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sha, err := a.runGitOutput(resp.RepoPath, "rev-parse", "main")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sha
+	}
+
+	first, second := generate(42), generate(42)
+	if first != second {
+		t.Fatalf("same seed produced %s and %s", first, second)
+	}
+	if other := generate(43); other == first {
+		t.Fatalf("seeds 42 and 43 both produced %s", first)
+	}
+	if generate(0) != generate(0) {
+		t.Fatal("unseeded generation is not reproducible")
+	}
+}
@@ -0,0 +1,205 @@
+// contribution_audit.go 在推送前审查生成的提交能否显示在个人贡献图上：
+// 当前账号的作者邮箱必须已验证，提交必须位于默认分支，仓库不能是 fork。
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// ContributionAuditRequest 定义贡献资格审查的参数。
+type ContributionAuditRequest struct {
+	RepoPath string `json:"repoPath"` // 本地仓库路径
+	RepoName string `json:"repoName"` // 远程仓库名，"repo" 或 "owner/repo"
+	Branch   string `json:"branch"`   // 目标推送分支，默认 main
+
+	SetDefaultBranch bool `json:"setDefaultBranch"` // 推送后会将目标分支设为默认分支
+}
+
+// ContributionAuditResult 列出会导致提交不计入贡献图的条件。
+type ContributionAuditResult struct {
+	Eligible         bool     `json:"eligible"`         // 所有条件均满足
+	AuthorEmails     []string `json:"authorEmails"`     // 生成的提交中出现的作者邮箱
+	UnverifiedEmails []string `json:"unverifiedEmails"` // 属于当前账号但尚未验证的作者邮箱
+	OtherEmails      []string `json:"otherEmails"`      // 不属于当前账号的作者邮箱（如多人署名时的其他作者），无法确认
+	EmailsChecked    bool     `json:"emailsChecked"`    // 是否成功读取账号邮箱（缺少 user:email 权限时为 false）
+	DefaultBranch    string   `json:"defaultBranch"`    // 仓库默认分支，新仓库为目标分支
+	IsDefaultBranch  bool     `json:"isDefaultBranch"`  // 目标分支是否为默认分支
+	IsFork           bool     `json:"isFork"`           // 仓库是否为 fork
+	IsPrivate        bool     `json:"isPrivate"`        // 仓库是否为私有
+	Problems         []string `json:"problems"`         // 会阻止提交显示的条件
+	Warnings         []string `json:"warnings"`         // 可能影响显示的条件
+}
+
+// AuditContributionEligibility 检查本地仓库中生成的提交推送到目标分支后是否会计入贡献图。
+func (a *App) AuditContributionEligibility(req ContributionAuditRequest) (*ContributionAuditResult, error) {
+	if a.userInfo == nil || a.userInfo.Token == "" {
+		return nil, fmt.Errorf("未登录")
+	}
+	branch := req.Branch
+	if branch == "" {
+		branch = "main"
+	}
+	LogInfo("审查贡献资格", zap.String("repo_path", req.RepoPath), zap.String("repo", req.RepoName), zap.String("branch", branch))
+
+	result := &ContributionAuditResult{
+		AuthorEmails:     []string{},
+		UnverifiedEmails: []string{},
+		OtherEmails:      []string{},
+		Problems:         []string{},
+		Warnings:         []string{},
+	}
+
+	// 1. 作者邮箱：只审查远程分支末端之后的提交，嫁接或重试推送时远程已有的提交不属于本次生成
+	base, remoteEmpty := a.auditBase(req.RepoPath, branch)
+	revision := "main"
+	if base != "" {
+		revision = base + "..main"
+	}
+	out, err := a.runGitOutput(req.RepoPath, "log", "--format=%ae", revision)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for _, email := range strings.Fields(out) {
+		if !seen[strings.ToLower(email)] {
+			seen[strings.ToLower(email)] = true
+			result.AuthorEmails = append(result.AuthorEmails, email)
+		}
+	}
+	sort.Strings(result.AuthorEmails)
+
+	// 只有当前账号自己的邮箱能确认验证状态；多人署名时其他作者的邮箱属于别的账号，只作提示
+	verified := make(map[string]bool) // 账号邮箱 -> 是否已验证
+	if a.userInfo.Email != "" {
+		verified[strings.ToLower(a.userInfo.Email)] = true
+	}
+	if emails, err := a.fetchGitHubUserEmails(a.userInfo.Token); err != nil {
+		LogWarn("读取账号邮箱失败", zap.Error(err))
+		result.Warnings = append(result.Warnings, "无法读取账号邮箱（可能缺少 user:email 权限），未能确认作者邮箱是否已验证")
+	} else {
+		result.EmailsChecked = true
+		for _, e := range emails {
+			verified[strings.ToLower(e.Email)] = e.Verified
+		}
+	}
+	if result.EmailsChecked {
+		own, unverified, others := classifyAuthorEmails(result.AuthorEmails, verified, a.userInfo.Username)
+		result.UnverifiedEmails = append(result.UnverifiedEmails, unverified...)
+		result.OtherEmails = append(result.OtherEmails, others...)
+		if len(result.UnverifiedEmails) > 0 {
+			result.Problems = append(result.Problems, fmt.Sprintf("作者邮箱 %s 未在 GitHub 账号 %s 中验证，提交不会计入贡献图",
+				strings.Join(result.UnverifiedEmails, ", "), a.userInfo.Username))
+		}
+		if len(result.OtherEmails) > 0 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("作者邮箱 %s 不属于账号 %s，无法确认这些提交能否计入对应账号的贡献图",
+				strings.Join(result.OtherEmails, ", "), a.userInfo.Username))
+		}
+		if !own && len(result.UnverifiedEmails) == 0 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("没有提交使用账号 %s 的已验证邮箱，提交不会计入你的贡献图", a.userInfo.Username))
+		}
+	}
+
+	// 2. 默认分支与 fork
+	var repo struct {
+		DefaultBranch string `json:"default_branch"`
+		Fork          bool   `json:"fork"`
+		Private       bool   `json:"private"`
+	}
+	status, err := a.githubAPI("GET", "/repos/"+a.fullRepoName(req.RepoName), nil, &repo)
+	if err != nil {
+		return nil, err
+	}
+	switch status {
+	case http.StatusOK:
+		result.DefaultBranch = repo.DefaultBranch
+		if remoteEmpty {
+			// 空仓库的第一个推送分支会成为默认分支
+			result.DefaultBranch = branch
+		}
+		result.IsFork = repo.Fork
+		result.IsPrivate = repo.Private
+	case http.StatusNotFound:
+		// 新仓库的第一个推送分支会成为默认分支
+		result.DefaultBranch = branch
+	default:
+		return nil, fmt.Errorf("获取仓库信息失败: %d", status)
+	}
+	result.IsDefaultBranch = result.DefaultBranch == branch
+	if !result.IsDefaultBranch && req.SetDefaultBranch {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("目标分支 %s 不是默认分支 %s，推送后需设为默认分支才会计入贡献图", branch, result.DefaultBranch))
+	} else if !result.IsDefaultBranch {
+		result.Problems = append(result.Problems, fmt.Sprintf("目标分支 %s 不是默认分支 %s，提交不会计入贡献图", branch, result.DefaultBranch))
+	}
+	if result.IsFork {
+		result.Problems = append(result.Problems, "仓库是 fork，fork 中的提交不会计入贡献图")
+	}
+	if result.IsPrivate {
+		result.Warnings = append(result.Warnings, "仓库为私有，需要在个人主页开启“显示私有贡献”才会显示")
+	}
+
+	result.Eligible = len(result.Problems) == 0
+	LogInfo("贡献资格审查完成",
+		zap.Bool("eligible", result.Eligible),
+		zap.Strings("unverified_emails", result.UnverifiedEmails),
+		zap.Strings("other_emails", result.OtherEmails),
+		zap.Bool("default_branch", result.IsDefaultBranch),
+		zap.Bool("fork", result.IsFork))
+	return result, nil
+}
+
+// auditBase 通过 ls-remote 查询远程目标分支的末端，返回本地存在的末端提交（用于排除远程已有的提交），
+// 以及远程仓库是否还没有任何分支。未配置 origin 或末端不在本地时返回空字符串，此时本地 main 上的提交均视为生成的提交。
+func (a *App) auditBase(repoPath, branch string) (string, bool) {
+	listing, err := a.runGitRemote(repoPath, "ls-remote", "--heads", "origin")
+	if err != nil {
+		LogInfo("无法查询远程分支，审查整个 main 分支", zap.Error(err))
+		return "", false
+	}
+	if listing == "" {
+		return "", true
+	}
+	for _, line := range strings.Split(listing, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[1] != "refs/heads/"+branch {
+			continue
+		}
+		if err := a.runGitCommand(repoPath, "cat-file", "-e", fields[0]+"^{commit}"); err == nil {
+			return fields[0], false
+		}
+	}
+	return "", false
+}
+
+// classifyAuthorEmails 将作者邮箱按账号归属分类。account 为账号邮箱（小写）到是否已验证的映射。
+// own 表示至少有一个邮箱是账号已验证的邮箱或 noreply 地址；unverified 为属于账号但未验证的邮箱；
+// others 为不属于账号的邮箱，例如多人署名时其他作者的邮箱。
+func classifyAuthorEmails(emails []string, account map[string]bool, login string) (own bool, unverified, others []string) {
+	for _, email := range emails {
+		verified, isOwn := account[strings.ToLower(email)]
+		switch {
+		case isUserNoreplyEmail(email, login) || isOwn && verified:
+			own = true
+		case isOwn:
+			unverified = append(unverified, email)
+		default:
+			others = append(others, email)
+		}
+	}
+	return own, unverified, others
+}
+
+// isUserNoreplyEmail 判断邮箱是否为该用户的 GitHub noreply 地址（login@ 或 id+login@users.noreply.github.com），
+// 这类地址无需验证即可关联到账号。
+func isUserNoreplyEmail(email, login string) bool {
+	local, domain, ok := strings.Cut(strings.ToLower(email), "@")
+	if !ok || domain != "users.noreply.github.com" {
+		return false
+	}
+	login = strings.ToLower(login)
+	return local == login || strings.HasSuffix(local, "+"+login)
+}
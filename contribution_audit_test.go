@@ -0,0 +1,115 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// 嫁接到已有远程分支后只审查生成的提交，远程已有提交的作者不应出现在审查范围内。
+func TestAuditBaseExcludesRemoteHistory(t *testing.T) {
+	a := newTestApp(t)
+	resp, err := a.GenerateRepo(GenerateRepoRequest{
+		Year:           2024,
+		GithubUsername: "tester",
+		GithubEmail:    "tester@example.com",
+		RepoName:       "audit",
+		Contributions:  []ContributionDay{{Date: "2024-02-01", Count: 2}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	if err := a.runGitCommand(filepath.Dir(remote), "init", "--bare", "-b", "main", remote); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.runGitCommand(resp.RepoPath, "remote", "add", "origin", remote); err != nil {
+		t.Fatal(err)
+	}
+	if base, empty := a.auditBase(resp.RepoPath, "main"); base != "" || !empty {
+		t.Fatalf("empty remote: base = %q, empty = %v", base, empty)
+	}
+
+	// 远程分支由其他作者创建
+	seed := filepath.Join(t.TempDir(), "seed")
+	for _, args := range [][]string{
+		{"init", "-b", "main", seed},
+		{"-C", seed, "-c", "user.name=Other", "-c", "user.email=other@example.com", "commit", "--allow-empty", "-m", "existing"},
+		{"-C", seed, "push", remote, "main"},
+	} {
+		if err := a.runGitCommand(t.TempDir(), args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tip, err := a.runGitOutput(seed, "rev-parse", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 远程末端尚未取回时，本地 main 上只有生成的提交
+	if base, empty := a.auditBase(resp.RepoPath, "main"); base != "" || empty {
+		t.Fatalf("unfetched remote: base = %q, empty = %v", base, empty)
+	}
+
+	if err := a.graftOntoRemote(resp.RepoPath, "main", graftModeOnTop); err != nil {
+		t.Fatal(err)
+	}
+	base, empty := a.auditBase(resp.RepoPath, "main")
+	if base != tip || empty {
+		t.Fatalf("grafted: base = %q, empty = %v, want %q", base, empty, tip)
+	}
+	emails, err := a.runGitOutput(resp.RepoPath, "log", "--format=%ae", base+"..main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(emails, "other@example.com") || !strings.Contains(emails, "tester@example.com") {
+		t.Fatalf("audited emails = %q", emails)
+	}
+}
+
+func TestIsUserNoreplyEmail(t *testing.T) {
+	tests := []struct {
+		email string
+		want  bool
+	}{
+		{"tester@users.noreply.github.com", true},
+		{"12345+Tester@users.noreply.github.com", true},
+		{"12345+other@users.noreply.github.com", false},
+		{"tester@example.com", false},
+		{"invalid", false},
+	}
+	for _, tt := range tests {
+		if got := isUserNoreplyEmail(tt.email, "tester"); got != tt.want {
+			t.Errorf("isUserNoreplyEmail(%q) = %v, want %v", tt.email, got, tt.want)
+		}
+	}
+}
+
+// 只有当前账号的未验证邮箱算作问题，其他作者（共同作者）的邮箱单独列出。
+func TestClassifyAuthorEmails(t *testing.T) {
+	account := map[string]bool{
+		"tester@example.com": true,
+		"old@example.com":    false,
+	}
+	tests := []struct {
+		name           string
+		emails         []string
+		wantOwn        bool
+		wantUnverified []string
+		wantOthers     []string
+	}{
+		{name: "verified", emails: []string{"Tester@Example.com"}, wantOwn: true},
+		{name: "noreply", emails: []string{"1+tester@users.noreply.github.com"}, wantOwn: true},
+		{name: "unverified", emails: []string{"old@example.com"}, wantUnverified: []string{"old@example.com"}},
+		{name: "co-author", emails: []string{"bob@example.com", "tester@example.com"}, wantOwn: true, wantOthers: []string{"bob@example.com"}},
+		{name: "only others", emails: []string{"bob@example.com"}, wantOthers: []string{"bob@example.com"}},
+	}
+	for _, tt := range tests {
+		own, unverified, others := classifyAuthorEmails(tt.emails, account, "tester")
+		if own != tt.wantOwn || !reflect.DeepEqual(unverified, tt.wantUnverified) || !reflect.DeepEqual(others, tt.wantOthers) {
+			t.Errorf("%s: got %v, %v, %v; want %v, %v, %v", tt.name, own, unverified, others, tt.wantOwn, tt.wantUnverified, tt.wantOthers)
+		}
+	}
+}
@@ -0,0 +1,344 @@
+// contribution_io.go 负责贡献数据在 JSON / CSV / TSV 格式之间的编码与解析。
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 支持的贡献数据文件格式。
+const (
+	contributionFormatJSON = "json"
+	contributionFormatCSV  = "csv"
+	contributionFormatTSV  = "tsv"
+)
+
+// 导入时同一日期出现多次的合并策略。
+const (
+	mergeStrategySum  = "sum"  // 累加
+	mergeStrategyMax  = "max"  // 取最大值
+	mergeStrategyLast = "last" // 以最后一次出现为准
+)
+
+// ImportRowError 描述导入文件中的一行错误数据。
+type ImportRowError struct {
+	Line    int    `json:"line"`    // 所在行号，从 1 开始
+	Content string `json:"content"` // 原始内容
+	Message string `json:"message"` // 错误原因
+}
+
+// ContributionImportError 汇总导入过程中发现的所有错误行。
+type ContributionImportError struct {
+	Rows []ImportRowError
+}
+
+func (e *ContributionImportError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d invalid row(s)", len(e.Rows))
+	for _, row := range e.Rows {
+		fmt.Fprintf(&sb, "; line %d: %s", row.Line, row.Message)
+	}
+	return sb.String()
+}
+
+// contributionDateLayouts 是导入时可识别的日期格式。
+var contributionDateLayouts = []string{"2006-01-02", "2006/01/02", time.RFC3339}
+
+// parseContributionDate 将多种常见日期格式统一转换为 YYYY-MM-DD。
+func parseContributionDate(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range contributionDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Format("2006-01-02"), nil
+		}
+	}
+	return "", fmt.Errorf("unrecognised date %q", value)
+}
+
+// detectContributionFormat 根据显式指定的格式、文件扩展名或内容推断文件格式，无法判断时默认为 JSON。
+func detectContributionFormat(format string, filePath string, data []byte) string {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case contributionFormatJSON, contributionFormatCSV, contributionFormatTSV:
+		return strings.ToLower(strings.TrimSpace(format))
+	}
+
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".json":
+		return contributionFormatJSON
+	case ".csv":
+		return contributionFormatCSV
+	case ".tsv", ".tab":
+		return contributionFormatTSV
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] == '[' || trimmed[0] == '{' {
+		return contributionFormatJSON
+	}
+	firstLine := trimmed
+	if i := bytes.IndexByte(firstLine, '\n'); i >= 0 {
+		firstLine = firstLine[:i]
+	}
+	if bytes.ContainsRune(firstLine, '\t') {
+		return contributionFormatTSV
+	}
+	return contributionFormatCSV
+}
+
+// encodeContributions 将贡献数据按指定格式编码，CSV/TSV 输出带表头并按日期排序。
+func encodeContributions(contributions []ContributionDay, format string) ([]byte, error) {
+	switch format {
+	case contributionFormatCSV, contributionFormatTSV:
+		sorted := make([]ContributionDay, len(contributions))
+		copy(sorted, contributions)
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Date < sorted[j].Date })
+
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		if format == contributionFormatTSV {
+			w.Comma = '\t'
+		}
+		if err := w.Write([]string{"date", "count"}); err != nil {
+			return nil, err
+		}
+		for _, c := range sorted {
+			if err := w.Write([]string{c.Date, strconv.Itoa(c.Count)}); err != nil {
+				return nil, err
+			}
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case contributionFormatJSON, "":
+		return json.MarshalIndent(contributions, "", "  ")
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// parseContributions 解析贡献数据文件，收集所有错误行后一并返回，并按合并策略处理重复日期。
+func parseContributions(data []byte, format string, strategy string) ([]ContributionDay, error) {
+	switch strategy {
+	case "":
+		strategy = mergeStrategyLast
+	case mergeStrategySum, mergeStrategyMax, mergeStrategyLast:
+	default:
+		return nil, fmt.Errorf("unsupported merge strategy %q", strategy)
+	}
+
+	var rows []ContributionDay
+	var rowErrors []ImportRowError
+	switch format {
+	case contributionFormatCSV, contributionFormatTSV:
+		rows, rowErrors = parseDelimitedContributions(data, format)
+	case contributionFormatJSON:
+		rows, rowErrors = parseJSONContributions(data)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+	if len(rowErrors) > 0 {
+		return nil, &ContributionImportError{Rows: rowErrors}
+	}
+
+	return mergeContributionRows(rows, strategy), nil
+}
+
+// parseDelimitedContributions 解析 CSV/TSV 内容。首行由 isContributionHeader 判断是否为表头，
+// 表头中的 date/count 列名可用于确定列顺序。
+func parseDelimitedContributions(data []byte, format string) ([]ContributionDay, []ImportRowError) {
+	r := csv.NewReader(bytes.NewReader(data))
+	if format == contributionFormatTSV {
+		r.Comma = '\t'
+	}
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	r.Comment = '#'
+
+	dateCol, countCol := 0, 1
+	var rows []ContributionDay
+	var rowErrors []ImportRowError
+	first := true
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			line := 0
+			if pe, ok := err.(*csv.ParseError); ok {
+				line = pe.Line
+			}
+			rowErrors = append(rowErrors, ImportRowError{Line: line, Message: err.Error()})
+			continue
+		}
+		line, _ := r.FieldPos(0)
+		content := strings.Join(record, string(r.Comma))
+
+		if first {
+			first = false
+			if isContributionHeader(record, &dateCol, &countCol) {
+				continue
+			}
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+
+		if dateCol >= len(record) || countCol >= len(record) {
+			rowErrors = append(rowErrors, ImportRowError{Line: line, Content: content, Message: "missing date or count column"})
+			continue
+		}
+		day, err := parseContributionFields(record[dateCol], record[countCol])
+		if err != nil {
+			rowErrors = append(rowErrors, ImportRowError{Line: line, Content: content, Message: err.Error()})
+			continue
+		}
+		rows = append(rows, day)
+	}
+	return rows, rowErrors
+}
+
+// 首行中形似日期或数字的单元格说明这是数据行（可能是错误的数据行，如 2024-02-30），而不是表头。
+var (
+	dateLikeCell   = regexp.MustCompile(`^\d{4}[-/]\d{1,2}[-/]\d{1,2}`)
+	numberLikeCell = regexp.MustCompile(`^[+-]?\d+(\.\d+)?$`)
+)
+
+// isContributionHeader 判断首行是否为表头：包含已知列名（date、count、contributions），
+// 或者没有任何形似日期、数字的单元格。表头中的列名用于更新列索引。
+func isContributionHeader(record []string, dateCol, countCol *int) bool {
+	names := make(map[string]int, len(record))
+	for i, field := range record {
+		names[strings.ToLower(strings.TrimSpace(field))] = i
+	}
+	known := false
+	if i, ok := names["date"]; ok {
+		*dateCol, known = i, true
+	}
+	if i, ok := names["count"]; ok {
+		*countCol, known = i, true
+	} else if i, ok := names["contributions"]; ok {
+		*countCol, known = i, true
+	}
+	if known {
+		return true
+	}
+
+	for _, field := range record {
+		field = strings.TrimSpace(field)
+		if dateLikeCell.MatchString(field) || numberLikeCell.MatchString(field) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseContributionFields 解析日期与提交数字段。
+func parseContributionFields(dateField, countField string) (ContributionDay, error) {
+	date, err := parseContributionDate(dateField)
+	if err != nil {
+		return ContributionDay{}, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(countField))
+	if err != nil {
+		return ContributionDay{}, fmt.Errorf("invalid count %q", strings.TrimSpace(countField))
+	}
+	if count < 0 {
+		return ContributionDay{}, fmt.Errorf("negative count %d", count)
+	}
+	return ContributionDay{Date: date, Count: count}, nil
+}
+
+// parseJSONContributions 逐个元素解析 JSON 数组，单个元素出错时记录其所在行号并继续解析。
+func parseJSONContributions(data []byte) ([]ContributionDay, []ImportRowError) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	lineAt := func(offset int64) int {
+		if offset > int64(len(data)) {
+			offset = int64(len(data))
+		}
+		return bytes.Count(data[:offset], []byte("\n")) + 1
+	}
+	syntaxError := func(err error) []ImportRowError {
+		line := lineAt(dec.InputOffset())
+		if se, ok := err.(*json.SyntaxError); ok {
+			line = lineAt(se.Offset)
+		}
+		return []ImportRowError{{Line: line, Message: err.Error()}}
+	}
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, syntaxError(err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return nil, []ImportRowError{{Line: 1, Message: "expected a JSON array of {date, count} objects"}}
+	}
+
+	var rows []ContributionDay
+	var rowErrors []ImportRowError
+	for dec.More() {
+		start := dec.InputOffset()
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return rows, append(rowErrors, syntaxError(err)...)
+		}
+		// InputOffset 位于上一个分隔符之后，跳过空白与逗号定位到元素本身
+		for start < int64(len(data)) && bytes.IndexByte([]byte(" \t\r\n,"), data[start]) >= 0 {
+			start++
+		}
+		line := lineAt(start)
+
+		var item struct {
+			Date  *string          `json:"date"`
+			Count *json.RawMessage `json:"count"`
+		}
+		if err := json.Unmarshal(raw, &item); err != nil || item.Date == nil || item.Count == nil {
+			rowErrors = append(rowErrors, ImportRowError{Line: line, Content: string(raw), Message: "expected an object with date and count"})
+			continue
+		}
+		day, err := parseContributionFields(*item.Date, string(*item.Count))
+		if err != nil {
+			rowErrors = append(rowErrors, ImportRowError{Line: line, Content: string(raw), Message: err.Error()})
+			continue
+		}
+		rows = append(rows, day)
+	}
+	if _, err := dec.Token(); err != nil {
+		rowErrors = append(rowErrors, syntaxError(err)...)
+	}
+	return rows, rowErrors
+}
+
+// mergeContributionRows 按合并策略处理重复日期，结果按日期升序排列。
+func mergeContributionRows(rows []ContributionDay, strategy string) []ContributionDay {
+	merged := make(map[string]int, len(rows))
+	for _, row := range rows {
+		existing, ok := merged[row.Date]
+		switch {
+		case !ok, strategy == mergeStrategyLast:
+			merged[row.Date] = row.Count
+		case strategy == mergeStrategySum:
+			merged[row.Date] = existing + row.Count
+		case strategy == mergeStrategyMax && row.Count > existing:
+			merged[row.Date] = row.Count
+		}
+	}
+
+	result := make([]ContributionDay, 0, len(merged))
+	for date, count := range merged {
+		result = append(result, ContributionDay{Date: date, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Date < result[j].Date })
+	return result
+}
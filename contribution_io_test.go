@@ -0,0 +1,188 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestIsContributionHeader(t *testing.T) {
+	tests := []struct {
+		record       []string
+		want         bool
+		wantDate     int
+		wantCountCol int
+	}{
+		{record: []string{"date", "count"}, want: true, wantDate: 0, wantCountCol: 1},
+		{record: []string{"Count", " Date "}, want: true, wantDate: 1, wantCountCol: 0},
+		{record: []string{"day", "contributions"}, want: true, wantDate: 0, wantCountCol: 1},
+		{record: []string{"when", "how many"}, want: true, wantDate: 0, wantCountCol: 1},
+		{record: []string{"2024-01-02", "3"}, want: false, wantDate: 0, wantCountCol: 1},
+		// 形似日期或数字的首行是错误的数据行，不能当作表头丢弃
+		{record: []string{"2024-02-30", "3"}, want: false, wantDate: 0, wantCountCol: 1},
+		{record: []string{"2024/13/01", "x"}, want: false, wantDate: 0, wantCountCol: 1},
+		{record: []string{"yesterday", "3"}, want: false, wantDate: 0, wantCountCol: 1},
+		{record: []string{"yesterday", "-1.5"}, want: false, wantDate: 0, wantCountCol: 1},
+	}
+	for _, tt := range tests {
+		dateCol, countCol := 0, 1
+		got := isContributionHeader(tt.record, &dateCol, &countCol)
+		if got != tt.want || dateCol != tt.wantDate || countCol != tt.wantCountCol {
+			t.Errorf("isContributionHeader(%q) = %v, columns %d/%d; want %v, %d/%d",
+				tt.record, got, dateCol, countCol, tt.want, tt.wantDate, tt.wantCountCol)
+		}
+	}
+}
+
+func TestParseContributions(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		data   string
+		want   []ContributionDay
+	}{
+		{
+			name:   "csv with header",
+			format: contributionFormatCSV,
+			data:   "date,count\n2024-01-03,2\n2024-01-02,1\n",
+			want:   []ContributionDay{{Date: "2024-01-02", Count: 1}, {Date: "2024-01-03", Count: 2}},
+		},
+		{
+			name:   "csv without header",
+			format: contributionFormatCSV,
+			data:   "2024-01-02,1\n\n# comment\n2024/01/03, 2\n",
+			want:   []ContributionDay{{Date: "2024-01-02", Count: 1}, {Date: "2024-01-03", Count: 2}},
+		},
+		{
+			name:   "csv with reordered columns",
+			format: contributionFormatCSV,
+			data:   "contributions,note,date\n4,busy,2024-01-02T10:00:00Z\n",
+			want:   []ContributionDay{{Date: "2024-01-02", Count: 4}},
+		},
+		{
+			name:   "tsv",
+			format: contributionFormatTSV,
+			data:   "date\tcount\n2024-01-02\t5\n",
+			want:   []ContributionDay{{Date: "2024-01-02", Count: 5}},
+		},
+		{
+			name:   "json",
+			format: contributionFormatJSON,
+			data:   `[{"date": "2024-01-02", "count": 1}, {"date": "2024/01/03", "count": 0}]`,
+			want:   []ContributionDay{{Date: "2024-01-02", Count: 1}, {Date: "2024-01-03", Count: 0}},
+		},
+		{
+			name:   "empty json array",
+			format: contributionFormatJSON,
+			data:   "[]",
+			want:   []ContributionDay{},
+		},
+	}
+	for _, tt := range tests {
+		got, err := parseContributions([]byte(tt.data), tt.format, "")
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseContributionsBadRows(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    string
+		data      string
+		wantLines []int
+	}{
+		{name: "invalid first row is not a header", format: contributionFormatCSV, data: "2024-02-30,3\n2024-03-01,1\n", wantLines: []int{1}},
+		{name: "every bad row is reported", format: contributionFormatCSV, data: "date,count\n2024-01-02,x\n2024-01-03,1\n2024-01-04,-2\n2024-01-05\n", wantLines: []int{2, 4, 5}},
+		{name: "unparseable date after header", format: contributionFormatTSV, data: "date\tcount\nyesterday\t1\n", wantLines: []int{2}},
+		{name: "csv syntax error", format: contributionFormatCSV, data: "2024-01-02,1\n\"2024-01-03,2\n", wantLines: []int{2}},
+		{name: "json element without count", format: contributionFormatJSON, data: "[\n  {\"date\": \"2024-01-02\", \"count\": 1},\n  {\"date\": \"2024-01-03\"}\n]", wantLines: []int{3}},
+		{name: "json bad count", format: contributionFormatJSON, data: "[\n  {\"date\": \"2024-01-02\", \"count\": \"many\"}\n]", wantLines: []int{2}},
+		{name: "json object instead of array", format: contributionFormatJSON, data: `{"date": "2024-01-02"}`, wantLines: []int{1}},
+	}
+	for _, tt := range tests {
+		_, err := parseContributions([]byte(tt.data), tt.format, "")
+		var importErr *ContributionImportError
+		if !errors.As(err, &importErr) {
+			t.Errorf("%s: error = %v, want a ContributionImportError", tt.name, err)
+			continue
+		}
+		var lines []int
+		for _, row := range importErr.Rows {
+			lines = append(lines, row.Line)
+		}
+		if !reflect.DeepEqual(lines, tt.wantLines) {
+			t.Errorf("%s: bad rows on lines %v, want %v (%v)", tt.name, lines, tt.wantLines, err)
+		}
+	}
+}
+
+func TestParseContributionsMergeStrategies(t *testing.T) {
+	data := []byte("date,count\n2024-01-02,3\n2024-01-03,1\n2024-01-02,5\n2024-01-02,2\n")
+	tests := []struct {
+		strategy string
+		want     int
+	}{
+		{strategy: "", want: 2},
+		{strategy: mergeStrategyLast, want: 2},
+		{strategy: mergeStrategySum, want: 10},
+		{strategy: mergeStrategyMax, want: 5},
+	}
+	for _, tt := range tests {
+		got, err := parseContributions(data, contributionFormatCSV, tt.strategy)
+		if err != nil {
+			t.Errorf("%q: %v", tt.strategy, err)
+			continue
+		}
+		want := []ContributionDay{{Date: "2024-01-02", Count: tt.want}, {Date: "2024-01-03", Count: 1}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%q: got %v, want %v", tt.strategy, got, want)
+		}
+	}
+	if _, err := parseContributions(data, contributionFormatCSV, "min"); err == nil {
+		t.Error("unsupported strategy: expected error")
+	}
+}
+
+func TestDetectContributionFormat(t *testing.T) {
+	tests := []struct {
+		format, path, data, want string
+	}{
+		{format: "TSV", path: "a.json", want: contributionFormatTSV},
+		{path: "a.csv", data: "[]", want: contributionFormatCSV},
+		{path: "a.tab", want: contributionFormatTSV},
+		{data: " [{}]", want: contributionFormatJSON},
+		{data: "date\tcount\n", want: contributionFormatTSV},
+		{data: "date,count\n", want: contributionFormatCSV},
+		{want: contributionFormatJSON},
+	}
+	for _, tt := range tests {
+		if got := detectContributionFormat(tt.format, tt.path, []byte(tt.data)); got != tt.want {
+			t.Errorf("detectContributionFormat(%q, %q, %q) = %q, want %q", tt.format, tt.path, tt.data, got, tt.want)
+		}
+	}
+}
+
+// 各格式导出的数据都能原样导入。
+func TestEncodeContributionsRoundTrip(t *testing.T) {
+	days := []ContributionDay{{Date: "2024-01-03", Count: 2}, {Date: "2024-01-02", Count: 1}}
+	want := []ContributionDay{{Date: "2024-01-02", Count: 1}, {Date: "2024-01-03", Count: 2}}
+	for _, format := range []string{contributionFormatCSV, contributionFormatTSV, contributionFormatJSON} {
+		data, err := encodeContributions(days, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := parseContributions(data, detectContributionFormat("", "", data), "")
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %v, want %v", format, got, want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// delta.go 实现增量生成模式：在用户已有贡献的基础上，只生成让图案按目标色阶呈现所需的额外提交。
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DeltaContributionsRequest 定义增量计算请求。
+type DeltaContributionsRequest struct {
+	Year     int               `json:"year"`     // 目标年份，为 0 时根据目标图案推断
+	Target   []ContributionDay `json:"target"`   // 目标图案（前端画布上的提交数）
+	Existing []ContributionDay `json:"existing"` // 用户当前的贡献日历，为空时从 GitHub 获取
+}
+
+// DeltaContributionsResponse 返回增量计算结果。
+type DeltaContributionsResponse struct {
+	Contributions []ContributionDay `json:"contributions"` // 每天需要额外生成的提交数
+	TotalCommits  int               `json:"totalCommits"`  // 额外提交总数
+	Warnings      []ShadingWarning  `json:"warnings"`      // 无法达到目标色阶的格子
+}
+
+// ComputeDeltaContributions 计算在已有贡献之上还需要生成多少提交，才能让贡献图呈现目标图案。
+// 如果请求中未提供已有贡献，将通过 GitHub API 获取当前登录用户的贡献日历。
+func (a *App) ComputeDeltaContributions(req DeltaContributionsRequest) (*DeltaContributionsResponse, error) {
+	year := req.Year
+	if year <= 0 {
+		year = inferContributionYear(req.Target)
+	}
+	if year <= 0 {
+		return nil, fmt.Errorf("cannot determine target year")
+	}
+
+	existing := req.Existing
+	if len(existing) == 0 {
+		fetched, err := a.FetchContributionCalendar(year)
+		if err != nil {
+			LogError("获取已有贡献失败", zap.Error(err))
+			return nil, fmt.Errorf("fetch existing contributions: %w", err)
+		}
+		existing = fetched
+	}
+
+	return computeDeltaContributions(year, req.Target, existing)
+}
+
+// computeDeltaContributions 根据目标图案与已有贡献计算每天需要额外生成的提交数。
+func computeDeltaContributions(year int, target []ContributionDay, existing []ContributionDay) (*DeltaContributionsResponse, error) {
+	dates := datesOfYear(year)
+	inYear := make(map[string]bool, len(dates))
+	for _, d := range dates {
+		inYear[d] = true
+	}
+
+	targetLevels := make(map[string]int, len(target))
+	for _, c := range target {
+		if c.Count < 0 {
+			return nil, fmt.Errorf("invalid contribution count for %s: %d", c.Date, c.Count)
+		}
+		if !inYear[c.Date] {
+			LogWarn("忽略目标年份之外的贡献", zap.String("date", c.Date), zap.Int("year", year))
+			continue
+		}
+		targetLevels[c.Date] = levelFromDrawingCount(c.Count)
+	}
+
+	baseline := make(map[string]int, len(existing))
+	for _, c := range existing {
+		if inYear[c.Date] && c.Count > 0 {
+			baseline[c.Date] += c.Count
+		}
+	}
+
+	final := solveShadingCounts(dates, targetLevels, baseline)
+
+	resp := &DeltaContributionsResponse{
+		Warnings: shadingWarnings(dates, targetLevels, baseline, final),
+	}
+	for _, date := range dates {
+		if extra := final[date] - baseline[date]; extra > 0 {
+			resp.Contributions = append(resp.Contributions, ContributionDay{Date: date, Count: extra})
+			resp.TotalCommits += extra
+		}
+	}
+
+	LogInfo("增量贡献计算完成",
+		zap.Int("year", year),
+		zap.Int("extra_commits", resp.TotalCommits),
+		zap.Int("warnings", len(resp.Warnings)))
+	return resp, nil
+}
+
+// datesOfYear 返回指定年份内每一天的日期字符串 (YYYY-MM-DD)，按时间升序排列。
+func datesOfYear(year int) []string {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+	var dates []string
+	for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+		dates = append(dates, d.Format("2006-01-02"))
+	}
+	return dates
+}
+
+// inferContributionYear 从贡献数据中推断目标年份（取最早一条记录所在的年份）。
+func inferContributionYear(contributions []ContributionDay) int {
+	var dates []string
+	for _, c := range contributions {
+		if len(c.Date) >= 4 {
+			dates = append(dates, c.Date)
+		}
+	}
+	if len(dates) == 0 {
+		return 0
+	}
+	sort.Strings(dates)
+	year, err := strconv.Atoi(dates[0][:4])
+	if err != nil {
+		return 0
+	}
+	return year
+}
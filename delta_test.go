@@ -0,0 +1,100 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComputeDeltaContributions(t *testing.T) {
+	tests := []struct {
+		name         string
+		target       []ContributionDay
+		existing     []ContributionDay
+		want         []ContributionDay
+		wantWarnings map[string]string // 日期 -> 警告原因
+	}{
+		{
+			name:   "added days on an empty calendar",
+			target: []ContributionDay{{Date: "2024-01-01", Count: 1}, {Date: "2024-01-02", Count: 20}},
+			want:   []ContributionDay{{Date: "2024-01-01", Count: 1}, {Date: "2024-01-02", Count: 2}},
+		},
+		{
+			// 已有贡献抬高了浅色格子，深色格子需要更多提交才能保持更深
+			name:     "changed days build on existing contributions",
+			target:   []ContributionDay{{Date: "2024-01-01", Count: 1}, {Date: "2024-01-02", Count: 5}},
+			existing: []ContributionDay{{Date: "2024-01-01", Count: 2}},
+			want:     []ContributionDay{{Date: "2024-01-02", Count: 3}},
+			// 只有两个非空格子时，分位数规则把较深的格子画成最深色
+			wantWarnings: map[string]string{"2024-01-02": shadingReasonDistribution},
+		},
+		{
+			name:         "existing contributions already reach the target",
+			target:       []ContributionDay{{Date: "2024-01-01", Count: 1}, {Date: "2024-01-02", Count: 5}},
+			existing:     []ContributionDay{{Date: "2024-01-01", Count: 1}, {Date: "2024-01-02", Count: 4}},
+			wantWarnings: map[string]string{"2024-01-02": shadingReasonExistingTooHigh},
+		},
+		{
+			// 已有贡献无法撤销，被擦除的格子只能给出警告
+			name:     "removed days keep their existing contributions",
+			target:   []ContributionDay{{Date: "2024-01-01", Count: 0}, {Date: "2024-01-02", Count: 20}},
+			existing: []ContributionDay{{Date: "2024-01-01", Count: 4}},
+			want:     []ContributionDay{{Date: "2024-01-02", Count: 1}},
+			wantWarnings: map[string]string{
+				"2024-01-01": shadingReasonExistingTooHigh,
+				"2024-01-02": shadingReasonDistribution,
+			},
+		},
+		{
+			name:         "days outside the year are ignored",
+			target:       []ContributionDay{{Date: "2023-12-31", Count: 20}, {Date: "2024-03-01", Count: 1}},
+			existing:     []ContributionDay{{Date: "2025-01-01", Count: 9}},
+			want:         []ContributionDay{{Date: "2024-03-01", Count: 1}},
+			wantWarnings: map[string]string{"2024-03-01": shadingReasonDistribution},
+		},
+	}
+	for _, tt := range tests {
+		resp, err := computeDeltaContributions(2024, tt.target, tt.existing)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(resp.Contributions, tt.want) {
+			t.Errorf("%s: contributions = %v, want %v", tt.name, resp.Contributions, tt.want)
+		}
+		total := 0
+		for _, c := range tt.want {
+			total += c.Count
+		}
+		if resp.TotalCommits != total {
+			t.Errorf("%s: total = %d, want %d", tt.name, resp.TotalCommits, total)
+		}
+		warnings := make(map[string]string)
+		for _, w := range resp.Warnings {
+			warnings[w.Date] = w.Reason
+		}
+		if len(warnings) != len(tt.wantWarnings) || (len(warnings) > 0 && !reflect.DeepEqual(warnings, tt.wantWarnings)) {
+			t.Errorf("%s: warnings = %+v, want %v", tt.name, resp.Warnings, tt.wantWarnings)
+		}
+	}
+
+	if _, err := computeDeltaContributions(2024, []ContributionDay{{Date: "2024-01-01", Count: -1}}, nil); err == nil {
+		t.Error("negative count: expected error")
+	}
+}
+
+func TestInferContributionYear(t *testing.T) {
+	tests := []struct {
+		days []ContributionDay
+		want int
+	}{
+		{days: nil, want: 0},
+		{days: []ContributionDay{{Date: "2025-01-01"}, {Date: "2024-12-31"}}, want: 2024},
+		{days: []ContributionDay{{Date: "x"}, {Date: "2023-05-01"}}, want: 2023},
+		{days: []ContributionDay{{Date: "abcd-01-01"}}, want: 0},
+	}
+	for _, tt := range tests {
+		if got := inferContributionYear(tt.days); got != tt.want {
+			t.Errorf("inferContributionYear(%v) = %d, want %d", tt.days, got, tt.want)
+		}
+	}
+}
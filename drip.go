@@ -0,0 +1,527 @@
+// drip.go 实现“滴灌”模式：持久化一份生成计划，每次运行只为当天（以及之前错过的日期）追加提交并推送，
+// 让图案在贡献图上逐日出现，而不是一次性回填整段历史。
+// 可以通过 cron / systemd 定时执行 `GreenWall drip`，也可以让应用保持打开，由后台定时器自动运行。
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// dripCheckInterval 是应用保持打开时检查滴灌计划的间隔。
+const dripCheckInterval = time.Hour
+
+// dripLockStaleAfter 是计划锁文件的过期时间。持有锁的进程崩溃后锁文件不会被删除，
+// 超过该时间的锁视为失效，一次运行（克隆、生成、推送）不应超过这个时间。
+const dripLockStaleAfter = 30 * time.Minute
+
+// DripPlan 是持久化在磁盘上的滴灌计划。
+type DripPlan struct {
+	Name          string            `json:"name"`          // 计划名称，同时作为文件名
+	RepoName      string            `json:"repoName"`      // 远程仓库，"repo" 或 "owner/repo"
+	Branch        string            `json:"branch"`        // 推送的分支，默认 main
+	AuthorName    string            `json:"authorName"`    // 提交作者名
+	AuthorEmail   string            `json:"authorEmail"`   // 提交作者邮箱
+	Language      string            `json:"language"`      // 生成代码使用的语言，默认 markdown
+	Contributions []ContributionDay `json:"contributions"` // 计划中每一天的提交数
+	Completed     map[string]int    `json:"completed"`     // 已生成提交的日期及提交数
+	Enabled       bool              `json:"enabled"`       // 是否由应用内定时器自动运行
+	CreatedAt     string            `json:"createdAt"`     // 创建时间 (RFC3339)
+	LastRunAt     string            `json:"lastRunAt"`     // 最近一次运行时间 (RFC3339)
+	LastError     string            `json:"lastError"`     // 最近一次运行的错误，成功时为空
+	// 签名、提交归属、提交说明、文件布局与提交时间等选项，与 GenerateRepo 含义相同，不支持 ScaffoldDate
+	GenerationOptions
+}
+
+// CreateDripPlanRequest 定义创建滴灌计划的请求。
+type CreateDripPlanRequest struct {
+	Name          string            `json:"name"`          // 计划名称
+	RepoName      string            `json:"repoName"`      // 远程仓库，需已存在
+	Branch        string            `json:"branch"`        // 推送的分支，默认 main
+	AuthorName    string            `json:"authorName"`    // 提交作者名，为空时依次使用登录用户与全局 git 配置
+	AuthorEmail   string            `json:"authorEmail"`   // 提交作者邮箱，为空时依次使用登录用户与全局 git 配置
+	Language      string            `json:"language"`      // 生成代码使用的语言
+	Contributions []ContributionDay `json:"contributions"` // 计划中每一天的提交数
+	Enabled       bool              `json:"enabled"`       // 是否由应用内定时器自动运行
+	// 生成选项，与 GenerateRepo 相同。模板额外文件随第一个提交加入，不支持单独的脚手架提交（ScaffoldDate）
+	GenerationOptions
+}
+
+// DripRunResult 是一次运行的结果。
+type DripRunResult struct {
+	Plan           string   `json:"plan"`           // 计划名称
+	Dates          []string `json:"dates"`          // 本次补上的日期（包含之前错过的日期）
+	CommitsCreated int      `json:"commitsCreated"` // 本次新建的提交数
+	Pushed         bool     `json:"pushed"`         // 是否推送成功
+	Remaining      int      `json:"remaining"`      // 计划中尚未到期的日期数
+	Message        string   `json:"message"`        // 结果说明
+}
+
+// CreateDripPlan 校验并保存一份滴灌计划。
+func (a *App) CreateDripPlan(req CreateDripPlanRequest) (*DripPlan, error) {
+	name, err := dripPlanName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.RepoName) == "" {
+		return nil, fmt.Errorf("repository name is required")
+	}
+	if _, err := os.Stat(a.dripPlanPath(name)); err == nil {
+		return nil, fmt.Errorf("drip plan %q already exists", name)
+	}
+
+	seen := make(map[string]bool)
+	var contributions []ContributionDay
+	for _, c := range req.Contributions {
+		if _, err := time.Parse("2006-01-02", c.Date); err != nil {
+			return nil, fmt.Errorf("invalid date %q: %w", c.Date, err)
+		}
+		if c.Count < 0 {
+			return nil, fmt.Errorf("invalid contribution count for %s: %d", c.Date, c.Count)
+		}
+		if seen[c.Date] {
+			return nil, fmt.Errorf("date %s appears more than once", c.Date)
+		}
+		seen[c.Date] = true
+		if c.Count > 0 {
+			contributions = append(contributions, c)
+		}
+	}
+	if len(contributions) == 0 {
+		return nil, fmt.Errorf("no contributions supplied")
+	}
+	sort.Slice(contributions, func(i, j int) bool { return contributions[i].Date < contributions[j].Date })
+
+	plan := &DripPlan{
+		Name:          name,
+		RepoName:      strings.TrimSpace(req.RepoName),
+		Branch:        strings.TrimSpace(req.Branch),
+		AuthorName:    strings.TrimSpace(req.AuthorName),
+		AuthorEmail:   strings.TrimSpace(req.AuthorEmail),
+		Language:      req.Language,
+		Contributions: contributions,
+		Completed:     make(map[string]int),
+		Enabled:       req.Enabled,
+		CreatedAt:     time.Now().Format(time.RFC3339),
+	}
+	if plan.Branch == "" {
+		plan.Branch = "main"
+	}
+	if plan.Language == "" {
+		plan.Language = "markdown"
+	}
+	plan.GenerationOptions = req.GenerationOptions
+	// 滴灌历史在第一次运行时就已开始，无法在之前的日期补一个脚手架提交
+	if plan.ScaffoldDate != "" {
+		return nil, fmt.Errorf("drip plans do not support a scaffold date: template files are added with the first commit")
+	}
+	if _, err := a.newDripPlanner(plan); err != nil {
+		return nil, err
+	}
+	if err := a.saveDripPlan(plan); err != nil {
+		return nil, err
+	}
+
+	LogInfo("创建滴灌计划",
+		zap.String("plan", plan.Name),
+		zap.String("repo", plan.RepoName),
+		zap.Int("days", len(plan.Contributions)))
+	return plan, nil
+}
+
+// ListDripPlans 返回所有已保存的滴灌计划，按名称排序。
+func (a *App) ListDripPlans() ([]DripPlan, error) {
+	entries, err := os.ReadDir(a.dripDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []DripPlan{}, nil
+		}
+		return nil, fmt.Errorf("read drip directory: %w", err)
+	}
+	plans := []DripPlan{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		plan, err := a.loadDripPlan(strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil {
+			LogWarn("读取滴灌计划失败", zap.String("file", entry.Name()), zap.Error(err))
+			continue
+		}
+		plans = append(plans, *plan)
+	}
+	return plans, nil
+}
+
+// DeleteDripPlan 删除滴灌计划及其本地仓库，远程仓库不受影响。
+func (a *App) DeleteDripPlan(name string) error {
+	name, err := dripPlanName(name)
+	if err != nil {
+		return err
+	}
+	// 运行中的计划不能删除，避免删除正在使用的仓库
+	unlock, err := a.lockDripPlan(name)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err := os.Remove(a.dripPlanPath(name)); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("drip plan %q not found", name)
+		}
+		return fmt.Errorf("remove drip plan: %w", err)
+	}
+	if err := os.RemoveAll(a.dripRepoPath(name)); err != nil {
+		return fmt.Errorf("remove drip repository: %w", err)
+	}
+	LogInfo("删除滴灌计划", zap.String("plan", name))
+	return nil
+}
+
+// RunDripPlan 立即运行一次滴灌计划：为今天及之前错过的日期追加提交并推送。
+// 已完成的日期会被跳过；推送失败时本地提交会保留，下次运行会重新推送。
+func (a *App) RunDripPlan(name string) (*DripRunResult, error) {
+	return a.runDripPlan(name, time.Now())
+}
+
+// runDripPlan 执行一次计划并保存运行结果。同一计划的运行通过锁文件互斥（包括命令行与应用内定时器同时运行），
+// 计划在获得锁之后才读取，因此不会基于过期的进度重复追加提交。
+func (a *App) runDripPlan(name string, now time.Time) (*DripRunResult, error) {
+	name, err := dripPlanName(name)
+	if err != nil {
+		return nil, err
+	}
+	unlock, err := a.lockDripPlan(name)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	plan, err := a.loadDripPlan(name)
+	if err != nil {
+		return nil, err
+	}
+	LogInfo("开始运行滴灌计划", zap.String("plan", plan.Name), zap.String("date", now.Format("2006-01-02")))
+
+	result, err := a.applyDripPlan(plan, now)
+	plan.LastRunAt = now.Format(time.RFC3339)
+	plan.LastError = ""
+	if err != nil {
+		plan.LastError = err.Error()
+	}
+	if saveErr := a.saveDripPlan(plan); saveErr != nil && err == nil {
+		err = saveErr
+	}
+	if err != nil {
+		LogError("滴灌计划运行失败", zap.String("plan", plan.Name), zap.Error(err))
+		return result, err
+	}
+
+	LogInfo("滴灌计划运行完成",
+		zap.String("plan", plan.Name),
+		zap.Int("commits", result.CommitsCreated),
+		zap.Int("remaining", result.Remaining))
+	return result, nil
+}
+
+// applyDripPlan 准备本地仓库、追加到期的提交并推送。
+func (a *App) applyDripPlan(plan *DripPlan, now time.Time) (*DripRunResult, error) {
+	result := &DripRunResult{Plan: plan.Name}
+	// 命令行与定时器运行时尚未加载登录信息
+	userInfo, err := a.ensureUserInfo()
+	if err != nil {
+		return result, err
+	}
+	if userInfo == nil || userInfo.Token == "" {
+		return result, fmt.Errorf("not logged in to GitHub")
+	}
+
+	today := now.Format("2006-01-02")
+	due := false
+	for _, c := range plan.Contributions {
+		switch {
+		case c.Date > today:
+			result.Remaining++
+		case plan.Completed[c.Date] < c.Count:
+			due = true
+		}
+	}
+
+	repoPath := a.dripRepoPath(plan.Name)
+	if err := a.prepareDripRepo(plan, repoPath); err != nil {
+		return result, err
+	}
+
+	if due {
+		added, err := a.appendDripCommits(repoPath, plan, today)
+		if err != nil {
+			return result, err
+		}
+		for _, c := range added {
+			if len(result.Dates) == 0 || result.Dates[len(result.Dates)-1] != c.Date {
+				result.Dates = append(result.Dates, c.Date)
+			}
+			plan.Completed[c.Date]++
+		}
+		result.CommitsCreated = len(added)
+	}
+
+	// 即使今天没有新提交也推送一次，以补上之前推送失败的提交
+	if _, err := a.runGitRemote(repoPath, "push", "origin", fmt.Sprintf("HEAD:%s", plan.Branch)); err != nil {
+		result.Message = "本地提交已保存，推送失败，将在下次运行时重试"
+		return result, fmt.Errorf("push: %w", err)
+	}
+	result.Pushed = true
+	if result.CommitsCreated == 0 {
+		result.Message = "今天没有需要补充的提交"
+	} else {
+		result.Message = fmt.Sprintf("已为 %d 天追加 %d 个提交", len(result.Dates), result.CommitsCreated)
+	}
+	return result, nil
+}
+
+// prepareDripRepo 确保本地仓库存在且与远程分支同步。首次运行时克隆远程仓库，
+// 远程分支不存在时在空仓库上开始。
+func (a *App) prepareDripRepo(plan *DripPlan, repoPath string) error {
+	remoteURL := a.githubRemoteURL(plan.RepoName)
+	if _, err := os.Stat(filepath.Join(repoPath, ".git")); os.IsNotExist(err) {
+		if err := os.MkdirAll(repoPath, 0o755); err != nil {
+			return fmt.Errorf("create drip repository: %w", err)
+		}
+		if err := a.runGitCommand(repoPath, "init"); err != nil {
+			return err
+		}
+		if err := a.runGitCommand(repoPath, "remote", "add", "origin", remoteURL); err != nil {
+			return err
+		}
+		_ = a.runGitCommand(repoPath, "config", "commit.gpgsign", "false")
+		_ = a.runGitCommand(repoPath, "config", "core.autocrlf", "false")
+	} else {
+		// 旧版本在 origin 中保存了 Token，每次运行都改写为不含凭据的地址
+		if err := a.runGitCommand(repoPath, "remote", "set-url", "origin", remoteURL); err != nil {
+			return err
+		}
+	}
+
+	exists, err := a.remoteBranchExists(repoPath, plan.Branch)
+	if err != nil {
+		return fmt.Errorf("query remote branch %s: %w", plan.Branch, err)
+	}
+	if !exists {
+		// 远程分支尚不存在时从空历史开始（或继续推送之前未推送成功的本地提交）
+		LogInfo("远程分支尚不存在，将从空历史开始", zap.String("branch", plan.Branch))
+		return nil
+	}
+	if _, err := a.runGitRemote(repoPath, "fetch", "origin", plan.Branch); err != nil {
+		return fmt.Errorf("fetch origin/%s: %w", plan.Branch, err)
+	}
+
+	// 本地分支落后于远程时快进；本地有尚未推送的提交时保持不变，由后续推送处理
+	if _, err := a.runGitOutput(repoPath, "rev-parse", "--verify", "-q", "HEAD"); err != nil {
+		return a.runGitCommand(repoPath, "checkout", "-f", "-B", "main", "FETCH_HEAD")
+	}
+	if err := a.runGitCommand(repoPath, "merge-base", "--is-ancestor", "HEAD", "FETCH_HEAD"); err == nil {
+		return a.runGitCommand(repoPath, "reset", "--hard", "FETCH_HEAD")
+	}
+	if err := a.runGitCommand(repoPath, "merge-base", "--is-ancestor", "FETCH_HEAD", "HEAD"); err != nil {
+		return fmt.Errorf("local drip repository has diverged from origin/%s", plan.Branch)
+	}
+	return nil
+}
+
+// newDripPlanner 用与 GenerateRepo 相同的规划器计算整个计划的提交，
+// 每次运行只写出其中到期且尚未完成的部分，因此各次运行的提交与一次性生成的结果一致。
+func (a *App) newDripPlanner(plan *DripPlan) (*generationPlanner, error) {
+	repoName := plan.RepoName
+	if i := strings.LastIndex(repoName, "/"); i >= 0 {
+		repoName = repoName[i+1:]
+	}
+	return a.newGenerationPlanner(GenerateRepoRequest{
+		GithubUsername:    plan.AuthorName,
+		GithubEmail:       plan.AuthorEmail,
+		RepoName:          repoName,
+		Contributions:     plan.Contributions,
+		Language:          plan.Language,
+		GenerationOptions: plan.GenerationOptions,
+	})
+}
+
+// appendDripCommits 通过 git fast-import 在当前分支末尾追加 today 及之前尚未完成的提交，返回追加的提交。
+func (a *App) appendDripCommits(repoPath string, plan *DripPlan, today string) ([]PlannedCommit, error) {
+	branch := "refs/heads/main"
+	parent, _ := a.runGitOutput(repoPath, "rev-parse", "--verify", "-q", "HEAD")
+
+	planner, err := a.newDripPlanner(plan)
+	if err != nil {
+		return nil, err
+	}
+	var stream bytes.Buffer
+	added, err := planner.writeFastImportFrom(&stream, branch, parent, func(commit *PlannedCommit) bool {
+		return commit.Date <= today && commit.index >= plan.Completed[commit.Date]
+	})
+	if err != nil {
+		return nil, fmt.Errorf("build fast-import stream: %w", err)
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	if err := a.runGitFastImport(repoPath, &stream); err != nil {
+		return nil, fmt.Errorf("fast-import failed: %w", err)
+	}
+	if plan.Signing != nil {
+		if err := a.configureSigning(repoPath, plan.Signing); err != nil {
+			return nil, err
+		}
+		// 只签名本次追加的提交，已推送的历史保持不变
+		if _, err := a.signCommits(repoPath, parent, branch); err != nil {
+			return nil, err
+		}
+	}
+	if err := a.runGitCommand(repoPath, "checkout", "-f", "main"); err != nil {
+		return nil, err
+	}
+	return added, nil
+}
+
+// startDripScheduler 在应用保持打开时定期运行已启用的滴灌计划，每个计划每天最多运行一次。
+func (a *App) startDripScheduler() {
+	go func() {
+		for {
+			a.runDueDripPlans(time.Now())
+			time.Sleep(dripCheckInterval)
+		}
+	}()
+}
+
+// runDueDripPlans 运行所有已启用且今天尚未成功运行过的计划。
+func (a *App) runDueDripPlans(now time.Time) {
+	plans, err := a.ListDripPlans()
+	if err != nil {
+		LogWarn("读取滴灌计划失败", zap.Error(err))
+		return
+	}
+	today := now.Format("2006-01-02")
+	for i := range plans {
+		plan := &plans[i]
+		if !plan.Enabled {
+			continue
+		}
+		if last, err := time.Parse(time.RFC3339, plan.LastRunAt); err == nil && plan.LastError == "" && last.Format("2006-01-02") == today {
+			continue
+		}
+		_, _ = a.runDripPlan(plan.Name, now)
+	}
+}
+
+// dripPlanName 规范化计划名称。名称同时用作文件名与目录名，
+// 拒绝 "."、".." 等会指向滴灌目录本身或其上级目录的名称。
+func dripPlanName(name string) (string, error) {
+	name = sanitiseRepoName(name)
+	if name == "" || name == "." || name == ".." || name != filepath.Base(name) {
+		return "", fmt.Errorf("invalid drip plan name %q", name)
+	}
+	return name, nil
+}
+
+// lockDripPlan 以 O_EXCL 创建计划旁的锁文件，保证同一计划不会被多个进程（命令行、定时任务、应用）同时运行。
+// 锁文件中记录持有者的进程号与时间；超过 dripLockStaleAfter 的锁视为持有者已崩溃，会被移除后重新获取。
+// 返回的函数释放锁。
+func (a *App) lockDripPlan(name string) (func(), error) {
+	if err := os.MkdirAll(a.dripDir(), 0o755); err != nil {
+		return nil, fmt.Errorf("create drip directory: %w", err)
+	}
+	path := a.dripLockPath(name)
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			fmt.Fprintf(f, "%d %s\n", os.Getpid(), time.Now().Format(time.RFC3339))
+			f.Close()
+			return func() {
+				if err := os.Remove(path); err != nil {
+					LogWarn("释放滴灌计划锁失败", zap.String("plan", name), zap.Error(err))
+				}
+			}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("lock drip plan: %w", err)
+		}
+		info, statErr := os.Stat(path)
+		if statErr != nil {
+			// 锁刚被释放，重试
+			continue
+		}
+		if age := time.Since(info.ModTime()); age < dripLockStaleAfter {
+			holder, _ := os.ReadFile(path)
+			return nil, fmt.Errorf("drip plan %q is already running (lock %s held by %s)", name, path, strings.TrimSpace(string(holder)))
+		}
+		LogWarn("移除过期的滴灌计划锁", zap.String("plan", name), zap.Time("locked_at", info.ModTime()))
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("remove stale drip lock: %w", err)
+		}
+	}
+	return nil, fmt.Errorf("drip plan %q is already running", name)
+}
+
+// dripDir 返回滴灌计划的存放目录。
+func (a *App) dripDir() string {
+	return filepath.Join(filepath.Dir(a.getUserInfoPath()), "drip")
+}
+
+func (a *App) dripPlanPath(name string) string {
+	return filepath.Join(a.dripDir(), name+".json")
+}
+
+// dripLockPath 返回计划锁文件的路径，与计划文件放在一起。
+func (a *App) dripLockPath(name string) string {
+	return filepath.Join(a.dripDir(), name+".lock")
+}
+
+// dripRepoPath 返回计划对应的持久化本地仓库路径。
+func (a *App) dripRepoPath(name string) string {
+	return filepath.Join(a.dripDir(), name)
+}
+
+func (a *App) loadDripPlan(name string) (*DripPlan, error) {
+	name, err := dripPlanName(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(a.dripPlanPath(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("drip plan %q not found", name)
+		}
+		return nil, fmt.Errorf("read drip plan: %w", err)
+	}
+	var plan DripPlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("parse drip plan %s: %w", name, err)
+	}
+	if plan.Completed == nil {
+		plan.Completed = make(map[string]int)
+	}
+	return &plan, nil
+}
+
+func (a *App) saveDripPlan(plan *DripPlan) error {
+	if err := os.MkdirAll(a.dripDir(), 0o755); err != nil {
+		return fmt.Errorf("create drip directory: %w", err)
+	}
+	data, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal drip plan: %w", err)
+	}
+	if err := os.WriteFile(a.dripPlanPath(plan.Name), data, 0o644); err != nil {
+		return fmt.Errorf("write drip plan: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDripPlanName(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "daily-art", want: "daily-art"},
+		{name: "  my plan ", want: "my-plan"},
+		{name: "a/b", want: "a-b"},
+		{name: "", wantErr: true},
+		{name: ".", wantErr: true},
+		{name: "..", wantErr: true},
+		{name: "/", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := dripPlanName(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("dripPlanName(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("dripPlanName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDeleteDripPlanRejectsParentDirectory(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("HOME", configDir)
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("AppData", configDir)
+
+	a := NewApp()
+	userInfo := a.getUserInfoPath()
+	if err := os.WriteFile(userInfo, []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"..", "."} {
+		req := CreateDripPlanRequest{
+			Name:          name,
+			RepoName:      "art",
+			AuthorName:    "Test",
+			AuthorEmail:   "test@example.com",
+			Contributions: []ContributionDay{{Date: "2024-01-01", Count: 1}},
+		}
+		if _, err := a.CreateDripPlan(req); err == nil {
+			t.Errorf("CreateDripPlan(%q) succeeded, want error", name)
+		}
+		if err := a.DeleteDripPlan(name); err == nil {
+			t.Errorf("DeleteDripPlan(%q) succeeded, want error", name)
+		}
+	}
+	if _, err := os.Stat(userInfo); err != nil {
+		t.Fatalf("config directory was removed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Dir(userInfo)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// 远程分支不存在时从空历史开始，远程仓库无法访问时返回错误，而不是当作分支不存在。
+func TestPrepareDripRepo(t *testing.T) {
+	a := newTestApp(t)
+	a.userInfo = &UserInfo{Username: "octo", Token: "gho_secret"}
+	bare := newBareRemote(t, a, "octo", "drip")
+	plan := &DripPlan{Name: "art", RepoName: "drip", Branch: "main"}
+
+	repoPath := filepath.Join(t.TempDir(), "art")
+	if err := a.prepareDripRepo(plan, repoPath); err != nil {
+		t.Fatalf("missing branch: %v", err)
+	}
+
+	seed := t.TempDir()
+	if err := a.runGitCommand(seed, "init", "-q", "-b", "main"); err != nil {
+		t.Fatal(err)
+	}
+	tip := commitFile(t, a, seed, "a.txt", "a\n")
+	if err := a.runGitCommand(seed, "push", "-q", bare, "main"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.prepareDripRepo(plan, repoPath); err != nil {
+		t.Fatal(err)
+	}
+	if head, err := a.runGitOutput(repoPath, "rev-parse", "HEAD"); err != nil || head != tip {
+		t.Fatalf("HEAD = %q, %v; want %s", head, err, tip)
+	}
+
+	missing := &DripPlan{Name: "gone", RepoName: "missing", Branch: "main"}
+	if err := a.prepareDripRepo(missing, filepath.Join(t.TempDir(), "gone")); err == nil {
+		t.Fatal("unreachable remote: expected error")
+	}
+}
+
+// 计划锁跨进程生效：锁文件存在时拒绝再次运行或删除，过期的锁会被移除。
+func TestLockDripPlan(t *testing.T) {
+	a := newTestApp(t)
+	unlock, err := a.lockDripPlan("art")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.lockDripPlan("art"); err == nil || !strings.Contains(err.Error(), "already running") {
+		t.Fatalf("second lock: error = %v, want already running", err)
+	}
+	if _, err := a.runDripPlan("art", time.Now()); err == nil || !strings.Contains(err.Error(), "already running") {
+		t.Fatalf("run while locked: error = %v", err)
+	}
+	if err := a.DeleteDripPlan("art"); err == nil || !strings.Contains(err.Error(), "already running") {
+		t.Fatalf("delete while locked: error = %v", err)
+	}
+	if _, err := a.lockDripPlan("other"); err != nil {
+		t.Fatalf("other plan: %v", err)
+	}
+	unlock()
+	if _, err := os.Stat(a.dripLockPath("art")); !os.IsNotExist(err) {
+		t.Fatalf("lock file still exists after unlock: %v", err)
+	}
+
+	// 崩溃的进程留下的锁在过期后可以重新获取
+	if _, err := a.lockDripPlan("art"); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-dripLockStaleAfter - time.Minute)
+	if err := os.Chtimes(a.dripLockPath("art"), old, old); err != nil {
+		t.Fatal(err)
+	}
+	unlock, err = a.lockDripPlan("art")
+	if err != nil {
+		t.Fatalf("stale lock: %v", err)
+	}
+	unlock()
+}
+
+// 滴灌计划与 GenerateRepo 共用生成选项：分多次运行追加的历史与一次性生成的仓库完全相同。
+func TestDripPlanMatchesGenerateRepo(t *testing.T) {
+	a := newTestApp(t)
+	a.userInfo = &UserInfo{Username: "octo", Token: "gho_secret"}
+	bare := newBareRemote(t, a, "octo", "drip")
+
+	contributions := []ContributionDay{
+		{Date: "2024-01-02", Count: 2},
+		{Date: "2024-01-05", Count: 1},
+		{Date: "2024-01-09", Count: 3},
+	}
+	var options GenerationOptions
+	options.Seed = 7
+	options.FileLayout = "daily"
+	options.CommitMessage = &CommitMessageOptions{Template: "Paint {{.Date}} #{{.Number}}"}
+	req := CreateDripPlanRequest{
+		Name:              "art",
+		RepoName:          "drip",
+		AuthorName:        "tester",
+		AuthorEmail:       "tester@example.com",
+		Language:          "go",
+		Contributions:     contributions,
+		GenerationOptions: options,
+	}
+	if _, err := a.CreateDripPlan(req); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := a.runDripPlan("art", time.Date(2024, 1, 5, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.CommitsCreated != 3 || result.Remaining != 1 || strings.Join(result.Dates, ",") != "2024-01-02,2024-01-05" {
+		t.Fatalf("first run = %+v", result)
+	}
+	if result, err = a.runDripPlan("art", time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	if result.CommitsCreated != 3 || result.Remaining != 0 {
+		t.Fatalf("second run = %+v", result)
+	}
+	dripped, err := a.runGitOutput(bare, "rev-parse", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := a.GenerateRepo(GenerateRepoRequest{
+		GithubUsername:    "tester",
+		GithubEmail:       "tester@example.com",
+		RepoName:          "drip",
+		Language:          "go",
+		Contributions:     contributions,
+		GenerationOptions: options,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated, err := a.runGitOutput(resp.RepoPath, "rev-parse", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dripped != generated {
+		log, _ := a.runGitOutput(bare, "log", "--format=%ad %s", "main")
+		t.Fatalf("drip history %s differs from generated %s:\n%s", dripped, generated, log)
+	}
+}
+
+func TestCreateDripPlanRejectsScaffoldDate(t *testing.T) {
+	a := newTestApp(t)
+	req := CreateDripPlanRequest{
+		Name:          "art",
+		RepoName:      "drip",
+		AuthorName:    "tester",
+		AuthorEmail:   "tester@example.com",
+		Contributions: []ContributionDay{{Date: "2024-01-02", Count: 1}},
+	}
+	req.ScaffoldDate = "2023-12-01"
+	if _, err := a.CreateDripPlan(req); err == nil || !strings.Contains(err.Error(), "scaffold date") {
+		t.Fatalf("error = %v, want scaffold date rejected", err)
+	}
+	req.ScaffoldDate, req.FileLayout = "", "weekly"
+	if _, err := a.CreateDripPlan(req); err == nil {
+		t.Fatal("invalid file layout: expected error")
+	}
+}
@@ -206,6 +206,9 @@ type TranslationDict = {
 	notifications: {
 		loginFirst: string;
 		pushSuccess: string;
+		pushWarnings: string;
+		auditProblems: string;
+		pushAnyway: string;
 		operationFailed: string;
 		selectedChar: string;
 		fillSuccess: string;
@@ -439,6 +442,9 @@ const translations: Record<Language, TranslationDict> = {
 		notifications: {
 			loginFirst: "Please login first",
 			pushSuccess: "Successfully pushed to GitHub!",
+			pushWarnings: "The commits may not show up on the contribution graph",
+			auditProblems: "These commits will not count towards your contribution graph. Push anyway?",
+			pushAnyway: "Push anyway",
 			operationFailed: "Operation failed",
 			selectedChar: "Selected {{char}} with intensity {{intensity}}",
 			fillSuccess: "Filled all contributions",
@@ -682,6 +688,9 @@ const translations: Record<Language, TranslationDict> = {
 		notifications: {
 			loginFirst: "请先登录",
 			pushSuccess: "成功推送到 GitHub！",
+			pushWarnings: "提交可能不会显示在贡献图上",
+			auditProblems: "这些提交不会计入你的贡献图，仍要推送吗？",
+			pushAnyway: "仍然推送",
 			operationFailed: "操作失败",
 			selectedChar: "已选择 {{char}}",
 			fillSuccess: "已填充所有贡献",
@@ -1,6 +1,6 @@
 // EditorPage.tsx 是应用的核心页面，提供了类似 GitHub 贡献图的画布、绘制工具栏以及最终的仓库生成/推送入口。
 import React, { useState, useCallback, useEffect, useRef, useMemo } from 'react';
-import { notification, Select, Button } from 'antd';
+import { notification, Select, Button, Modal } from 'antd';
 import { LeftOutlined, RightOutlined } from '@ant-design/icons';
 import { GridCanvas } from '../components/Editor/GridCanvas';
 import { EditorToolBar } from '../components/Editor/EditorToolBar';
@@ -14,6 +14,7 @@ import { useTranslations } from '../i18n';
 import {
     GenerateRepo,
     PushToGitHub,
+    AuditContributionEligibility,
     LoadUserInfo,
     StartOAuthLogin,
     Logout,
@@ -354,6 +355,23 @@ export const EditorPage: React.FC<Props> = ({
 
             const genResult = await GenerateRepo(generatePayload);
 
+            // 推送前审查提交能否计入贡献图，存在问题时由用户确认是否继续
+            const audit = await AuditContributionEligibility(models.main.ContributionAuditRequest.createFrom({
+                repoPath: genResult.repoPath,
+                repoName: params.repoName,
+                branch: params.branch,
+            })).catch(() => null);
+            if (audit && audit.problems.length > 0) {
+                const confirmed = await new Promise<boolean>((resolve) => Modal.confirm({
+                    title: t('notifications.auditProblems'),
+                    content: audit.problems.join('\n'),
+                    okText: t('notifications.pushAnyway'),
+                    onOk: () => resolve(true),
+                    onCancel: () => resolve(false),
+                }));
+                if (!confirmed) return;
+            }
+
             const pushPayload = models.main.PushRepoRequest.createFrom({
                 repoPath: genResult.repoPath,
                 repoName: params.repoName,
@@ -370,6 +388,12 @@ export const EditorPage: React.FC<Props> = ({
                 message: t('notifications.pushSuccess'),
                 description: `Repo: ${pushResult.repoUrl}`
             });
+            if (pushResult.warnings?.length) {
+                notification.warning({
+                    message: t('notifications.pushWarnings'),
+                    description: pushResult.warnings.join('\n')
+                });
+            }
             setShowPushDialog(false);
         } catch (e: any) {
             notification.error({ message: t('notifications.operationFailed'), description: e.message });
@@ -2,38 +2,94 @@
 // This file is automatically generated. DO NOT EDIT
 import {main} from '../models';
 
+export function AuditContributionEligibility(arg1:main.ContributionAuditRequest):Promise<main.ContributionAuditResult>;
+
 export function CancelOAuthLogin():Promise<void>;
 
 export function CheckGitInstalled():Promise<main.CheckGitInstalledResponse>;
 
+export function CheckPushTarget(arg1:string,arg2:string,arg3:boolean):Promise<main.BranchCheckResult>;
+
+export function ComputeDeltaContributions(arg1:main.DeltaContributionsRequest):Promise<main.DeltaContributionsResponse>;
+
+export function ConvertImageToContributions(arg1:main.ImageToContributionsRequest):Promise<main.ImageToContributionsResponse>;
+
+export function CreateDripPlan(arg1:main.CreateDripPlanRequest):Promise<main.DripPlan>;
+
 export function CreateGitHubRepo(arg1:string,arg2:boolean):Promise<main.GitHubRepo>;
 
+export function DeleteDripPlan(arg1:string):Promise<void>;
+
+export function DeleteWorkspaceRepo(arg1:string):Promise<void>;
+
+export function ExportContributionImage(arg1:main.ExportImageRequest):Promise<main.ExportContributionsResponse>;
+
 export function ExportContributions(arg1:main.ExportContributionsRequest):Promise<main.ExportContributionsResponse>;
 
+export function FetchContributionCalendar(arg1:number):Promise<Array<main.ContributionDay>>;
+
+export function GenerateMultiYearRepo(arg1:main.MultiYearRepoRequest):Promise<main.MultiYearRepoResponse>;
+
 export function GenerateRepo(arg1:main.GenerateRepoRequest):Promise<main.GenerateRepoResponse>;
 
 export function GetGitPath():Promise<string>;
 
+export function GetLastPushBackup():Promise<main.PushBackup>;
+
 export function GetRepoBranches(arg1:string,arg2:string):Promise<Array<string>>;
 
 export function GetSupportedLanguagesAPI():Promise<Array<Record<string, string>>>;
 
 export function GetUserRepos():Promise<Array<main.GitHubRepo>>;
 
+export function GetWorkspacePath():Promise<string>;
+
 export function Greet(arg1:string):Promise<string>;
 
+export function ImportBannerFont(arg1:string):Promise<string>;
+
 export function ImportContributions():Promise<main.ImportContributionsResponse>;
 
+export function ImportContributionsWithOptions(arg1:main.ImportContributionsRequest):Promise<main.ImportContributionsResponse>;
+
+export function ListBannerFonts():Promise<Array<string>>;
+
+export function ListDripPlans():Promise<Array<main.DripPlan>>;
+
+export function ListWorkspaceRepos():Promise<Array<main.WorkspaceRepo>>;
+
+export function LoadProject():Promise<main.LoadProjectResponse>;
+
 export function LoadUserInfo():Promise<main.UserInfo>;
 
 export function Logout():Promise<void>;
 
+export function OpenWorkspaceRepo(arg1:string):Promise<void>;
+
+export function PlanGeneration(arg1:main.GenerateRepoRequest):Promise<main.GenerationPlan>;
+
+export function PlanMarquee(arg1:main.MarqueeRequest):Promise<main.MarqueeResponse>;
+
 export function PushToGitHub(arg1:main.PushRepoRequest):Promise<main.PushRepoResponse>;
 
+export function RenderTextBanner(arg1:main.TextBannerRequest):Promise<main.TextBannerResponse>;
+
+export function RetryPush(arg1:string):Promise<main.PushRepoResponse>;
+
+export function RunDripPlan(arg1:string):Promise<main.DripRunResult>;
+
+export function SaveProject(arg1:main.SaveProjectRequest):Promise<main.SaveProjectResponse>;
+
 export function SaveUserInfo(arg1:main.UserInfo):Promise<void>;
 
+export function SetDefaultBranch(arg1:string,arg2:string):Promise<void>;
+
 export function SetGitPath(arg1:main.SetGitPathRequest):Promise<main.SetGitPathResponse>;
 
+export function SolveLevelCounts(arg1:main.SolveLevelCountsRequest):Promise<main.SolveLevelCountsResponse>;
+
 export function StartOAuthLogin():Promise<main.LoginResponse>;
 
+export function UndoLastPush():Promise<main.PushRepoResponse>;
+
 export function VerifyGitHubToken():Promise<void>;
@@ -2,6 +2,10 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AuditContributionEligibility(arg1) {
+  return window['go']['main']['App']['AuditContributionEligibility'](arg1);
+}
+
 export function CancelOAuthLogin() {
   return window['go']['main']['App']['CancelOAuthLogin']();
 }
@@ -10,14 +14,50 @@ export function CheckGitInstalled() {
   return window['go']['main']['App']['CheckGitInstalled']();
 }
 
+export function CheckPushTarget(arg1, arg2, arg3) {
+  return window['go']['main']['App']['CheckPushTarget'](arg1, arg2, arg3);
+}
+
+export function ComputeDeltaContributions(arg1) {
+  return window['go']['main']['App']['ComputeDeltaContributions'](arg1);
+}
+
+export function ConvertImageToContributions(arg1) {
+  return window['go']['main']['App']['ConvertImageToContributions'](arg1);
+}
+
+export function CreateDripPlan(arg1) {
+  return window['go']['main']['App']['CreateDripPlan'](arg1);
+}
+
 export function CreateGitHubRepo(arg1, arg2) {
   return window['go']['main']['App']['CreateGitHubRepo'](arg1, arg2);
 }
 
+export function DeleteDripPlan(arg1) {
+  return window['go']['main']['App']['DeleteDripPlan'](arg1);
+}
+
+export function DeleteWorkspaceRepo(arg1) {
+  return window['go']['main']['App']['DeleteWorkspaceRepo'](arg1);
+}
+
+export function ExportContributionImage(arg1) {
+  return window['go']['main']['App']['ExportContributionImage'](arg1);
+}
+
 export function ExportContributions(arg1) {
   return window['go']['main']['App']['ExportContributions'](arg1);
 }
 
+export function FetchContributionCalendar(arg1) {
+  return window['go']['main']['App']['FetchContributionCalendar'](arg1);
+}
+
+export function GenerateMultiYearRepo(arg1) {
+  return window['go']['main']['App']['GenerateMultiYearRepo'](arg1);
+}
+
 export function GenerateRepo(arg1) {
   return window['go']['main']['App']['GenerateRepo'](arg1);
 }
@@ -26,6 +66,10 @@ export function GetGitPath() {
   return window['go']['main']['App']['GetGitPath']();
 }
 
+export function GetLastPushBackup() {
+  return window['go']['main']['App']['GetLastPushBackup']();
+}
+
 export function GetRepoBranches(arg1, arg2) {
   return window['go']['main']['App']['GetRepoBranches'](arg1, arg2);
 }
@@ -38,14 +82,42 @@ export function GetUserRepos() {
   return window['go']['main']['App']['GetUserRepos']();
 }
 
+export function GetWorkspacePath() {
+  return window['go']['main']['App']['GetWorkspacePath']();
+}
+
 export function Greet(arg1) {
   return window['go']['main']['App']['Greet'](arg1);
 }
 
+export function ImportBannerFont(arg1) {
+  return window['go']['main']['App']['ImportBannerFont'](arg1);
+}
+
 export function ImportContributions() {
   return window['go']['main']['App']['ImportContributions']();
 }
 
+export function ImportContributionsWithOptions(arg1) {
+  return window['go']['main']['App']['ImportContributionsWithOptions'](arg1);
+}
+
+export function ListBannerFonts() {
+  return window['go']['main']['App']['ListBannerFonts']();
+}
+
+export function ListDripPlans() {
+  return window['go']['main']['App']['ListDripPlans']();
+}
+
+export function ListWorkspaceRepos() {
+  return window['go']['main']['App']['ListWorkspaceRepos']();
+}
+
+export function LoadProject() {
+  return window['go']['main']['App']['LoadProject']();
+}
+
 export function LoadUserInfo() {
   return window['go']['main']['App']['LoadUserInfo']();
 }
@@ -54,22 +126,62 @@ export function Logout() {
   return window['go']['main']['App']['Logout']();
 }
 
+export function OpenWorkspaceRepo(arg1) {
+  return window['go']['main']['App']['OpenWorkspaceRepo'](arg1);
+}
+
+export function PlanGeneration(arg1) {
+  return window['go']['main']['App']['PlanGeneration'](arg1);
+}
+
+export function PlanMarquee(arg1) {
+  return window['go']['main']['App']['PlanMarquee'](arg1);
+}
+
 export function PushToGitHub(arg1) {
   return window['go']['main']['App']['PushToGitHub'](arg1);
 }
 
+export function RenderTextBanner(arg1) {
+  return window['go']['main']['App']['RenderTextBanner'](arg1);
+}
+
+export function RetryPush(arg1) {
+  return window['go']['main']['App']['RetryPush'](arg1);
+}
+
+export function RunDripPlan(arg1) {
+  return window['go']['main']['App']['RunDripPlan'](arg1);
+}
+
+export function SaveProject(arg1) {
+  return window['go']['main']['App']['SaveProject'](arg1);
+}
+
 export function SaveUserInfo(arg1) {
   return window['go']['main']['App']['SaveUserInfo'](arg1);
 }
 
+export function SetDefaultBranch(arg1, arg2) {
+  return window['go']['main']['App']['SetDefaultBranch'](arg1, arg2);
+}
+
 export function SetGitPath(arg1) {
   return window['go']['main']['App']['SetGitPath'](arg1);
 }
 
+export function SolveLevelCounts(arg1) {
+  return window['go']['main']['App']['SolveLevelCounts'](arg1);
+}
+
 export function StartOAuthLogin() {
   return window['go']['main']['App']['StartOAuthLogin']();
 }
 
+export function UndoLastPush() {
+  return window['go']['main']['App']['UndoLastPush']();
+}
+
 export function VerifyGitHubToken() {
   return window['go']['main']['App']['VerifyGitHubToken']();
 }
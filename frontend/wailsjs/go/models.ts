@@ -1,5 +1,39 @@
 export namespace main {
 	
+	export class BranchCheckResult {
+	    repoName: string;
+	    branch: string;
+	    repoExists: boolean;
+	    branchExists: boolean;
+	    defaultBranch: string;
+	    isDefault: boolean;
+	    protected: boolean;
+	    allowForcePushes: boolean;
+	    canPush: boolean;
+	    canSetDefault: boolean;
+	    problems: string[];
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new BranchCheckResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.repoName = source["repoName"];
+	        this.branch = source["branch"];
+	        this.repoExists = source["repoExists"];
+	        this.branchExists = source["branchExists"];
+	        this.defaultBranch = source["defaultBranch"];
+	        this.isDefault = source["isDefault"];
+	        this.protected = source["protected"];
+	        this.allowForcePushes = source["allowForcePushes"];
+	        this.canPush = source["canPush"];
+	        this.canSetDefault = source["canSetDefault"];
+	        this.problems = source["problems"];
+	        this.warnings = source["warnings"];
+	    }
+	}
 	export class CheckGitInstalledResponse {
 	    installed: boolean;
 	    version: string;
@@ -14,6 +48,74 @@ export namespace main {
 	        this.version = source["version"];
 	    }
 	}
+	export class CommitIdentity {
+	    name: string;
+	    email: string;
+	    nameSource: string;
+	    emailSource: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitIdentity(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.email = source["email"];
+	        this.nameSource = source["nameSource"];
+	        this.emailSource = source["emailSource"];
+	    }
+	}
+	export class ContributionAuditRequest {
+	    repoPath: string;
+	    repoName: string;
+	    branch: string;
+	    setDefaultBranch: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContributionAuditRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.repoPath = source["repoPath"];
+	        this.repoName = source["repoName"];
+	        this.branch = source["branch"];
+	        this.setDefaultBranch = source["setDefaultBranch"];
+	    }
+	}
+	export class ContributionAuditResult {
+	    eligible: boolean;
+	    authorEmails: string[];
+	    unverifiedEmails: string[];
+	    otherEmails: string[];
+	    emailsChecked: boolean;
+	    defaultBranch: string;
+	    isDefaultBranch: boolean;
+	    isFork: boolean;
+	    isPrivate: boolean;
+	    problems: string[];
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ContributionAuditResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.eligible = source["eligible"];
+	        this.authorEmails = source["authorEmails"];
+	        this.unverifiedEmails = source["unverifiedEmails"];
+	        this.otherEmails = source["otherEmails"];
+	        this.emailsChecked = source["emailsChecked"];
+	        this.defaultBranch = source["defaultBranch"];
+	        this.isDefaultBranch = source["isDefaultBranch"];
+	        this.isFork = source["isFork"];
+	        this.isPrivate = source["isPrivate"];
+	        this.problems = source["problems"];
+	        this.warnings = source["warnings"];
+	    }
+	}
 	export class ContributionDay {
 	    date: string;
 	    count: number;
@@ -28,90 +130,114 @@ export namespace main {
 	        this.count = source["count"];
 	    }
 	}
-	export class ExportContributionsRequest {
-	    contributions: ContributionDay[];
+	export class CommitMessageOptions {
+	    template: string;
+	    words: string[];
+	    conventionalTypes: string[];
+	    scope: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new ExportContributionsRequest(source);
+	        return new CommitMessageOptions(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.contributions = this.convertValues(source["contributions"], ContributionDay);
+	        this.template = source["template"];
+	        this.words = source["words"];
+	        this.conventionalTypes = source["conventionalTypes"];
+	        this.scope = source["scope"];
 	    }
+	}
+	export class GitIdentity {
+	    name: string;
+	    email: string;
 	
-		convertValues(a: any, classs: any, asMap: boolean = false): any {
-		    if (!a) {
-		        return a;
-		    }
-		    if (a.slice && a.map) {
-		        return (a as any[]).map(elem => this.convertValues(elem, classs));
-		    } else if ("object" === typeof a) {
-		        if (asMap) {
-		            for (const key of Object.keys(a)) {
-		                a[key] = new classs(a[key]);
-		            }
-		            return a;
-		        }
-		        return new classs(a);
-		    }
-		    return a;
-		}
+	    static createFrom(source: any = {}) {
+	        return new GitIdentity(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.email = source["email"];
+	    }
 	}
-	export class ExportContributionsResponse {
-	    success: boolean;
-	    message: string;
-	    filePath: string;
+	export class WeightedIdentity {
+	    name: string;
+	    email: string;
+	    weight: number;
+	    dates: string[];
 	
 	    static createFrom(source: any = {}) {
-	        return new ExportContributionsResponse(source);
+	        return new WeightedIdentity(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.success = source["success"];
-	        this.message = source["message"];
-	        this.filePath = source["filePath"];
+	        this.name = source["name"];
+	        this.email = source["email"];
+	        this.weight = source["weight"];
+	        this.dates = source["dates"];
 	    }
 	}
-	export class LanguageConfig {
-	    language: string;
-	    ratio: number;
+	export class SigningOptions {
+	    format: string;
+	    key: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new LanguageConfig(source);
+	        return new SigningOptions(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.language = source["language"];
-	        this.ratio = source["ratio"];
+	        this.format = source["format"];
+	        this.key = source["key"];
 	    }
 	}
-	export class GenerateRepoRequest {
-	    year: number;
-	    githubUsername: string;
-	    githubEmail: string;
+	export class CreateDripPlanRequest {
+	    name: string;
 	    repoName: string;
-	    contributions: ContributionDay[];
+	    branch: string;
+	    authorName: string;
+	    authorEmail: string;
 	    language: string;
-	    languageConfigs: LanguageConfig[];
-	    multiLanguage: boolean;
+	    contributions: ContributionDay[];
+	    enabled: boolean;
+	    // Go type: SigningOptions
+	    signing?: any;
+	    authors: WeightedIdentity[];
+	    committer?: GitIdentity;
+	    coAuthors: GitIdentity[];
+	    // Go type: CommitMessageOptions
+	    commitMessage?: any;
+	    fileLayout: string;
+	    contentMode: string;
+	    seed: number;
+	    scaffoldDate: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new GenerateRepoRequest(source);
+	        return new CreateDripPlanRequest(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.year = source["year"];
-	        this.githubUsername = source["githubUsername"];
-	        this.githubEmail = source["githubEmail"];
+	        this.name = source["name"];
 	        this.repoName = source["repoName"];
-	        this.contributions = this.convertValues(source["contributions"], ContributionDay);
+	        this.branch = source["branch"];
+	        this.authorName = source["authorName"];
+	        this.authorEmail = source["authorEmail"];
 	        this.language = source["language"];
-	        this.languageConfigs = this.convertValues(source["languageConfigs"], LanguageConfig);
-	        this.multiLanguage = source["multiLanguage"];
+	        this.contributions = this.convertValues(source["contributions"], ContributionDay);
+	        this.enabled = source["enabled"];
+	        this.signing = this.convertValues(source["signing"], null);
+	        this.authors = this.convertValues(source["authors"], WeightedIdentity);
+	        this.committer = this.convertValues(source["committer"], GitIdentity);
+	        this.coAuthors = this.convertValues(source["coAuthors"], GitIdentity);
+	        this.commitMessage = this.convertValues(source["commitMessage"], null);
+	        this.fileLayout = source["fileLayout"];
+	        this.contentMode = source["contentMode"];
+	        this.seed = source["seed"];
+	        this.scaffoldDate = source["scaffoldDate"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -132,50 +258,34 @@ export namespace main {
 		    return a;
 		}
 	}
-	export class GenerateRepoResponse {
-	    repoPath: string;
-	    commitCount: number;
-	
-	    static createFrom(source: any = {}) {
-	        return new GenerateRepoResponse(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.repoPath = source["repoPath"];
-	        this.commitCount = source["commitCount"];
-	    }
-	}
-	export class GitHubRepo {
-	    name: string;
-	    full_name: string;
-	    private: boolean;
-	    html_url: string;
-	    default_branch: string;
+	export class DayLevel {
+	    date: string;
+	    level: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new GitHubRepo(source);
+	        return new DayLevel(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.name = source["name"];
-	        this.full_name = source["full_name"];
-	        this.private = source["private"];
-	        this.html_url = source["html_url"];
-	        this.default_branch = source["default_branch"];
+	        this.date = source["date"];
+	        this.level = source["level"];
 	    }
 	}
-	export class ImportContributionsResponse {
-	    contributions: ContributionDay[];
+	export class DeltaContributionsRequest {
+	    year: number;
+	    target: ContributionDay[];
+	    existing: ContributionDay[];
 	
 	    static createFrom(source: any = {}) {
-	        return new ImportContributionsResponse(source);
+	        return new DeltaContributionsRequest(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.contributions = this.convertValues(source["contributions"], ContributionDay);
+	        this.year = source["year"];
+	        this.target = this.convertValues(source["target"], ContributionDay);
+	        this.existing = this.convertValues(source["existing"], ContributionDay);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -196,39 +306,40 @@ export namespace main {
 		    return a;
 		}
 	}
-	
-	export class UserInfo {
-	    username: string;
-	    email: string;
-	    token: string;
-	    avatarUrl: string;
+	export class ShadingWarning {
+	    date: string;
+	    targetLevel: number;
+	    actualLevel: number;
+	    existing: number;
+	    reason: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new UserInfo(source);
+	        return new ShadingWarning(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.username = source["username"];
-	        this.email = source["email"];
-	        this.token = source["token"];
-	        this.avatarUrl = source["avatarUrl"];
+	        this.date = source["date"];
+	        this.targetLevel = source["targetLevel"];
+	        this.actualLevel = source["actualLevel"];
+	        this.existing = source["existing"];
+	        this.reason = source["reason"];
 	    }
 	}
-	export class LoginResponse {
-	    success: boolean;
-	    message: string;
-	    userInfo?: UserInfo;
+	export class DeltaContributionsResponse {
+	    contributions: ContributionDay[];
+	    totalCommits: number;
+	    warnings: ShadingWarning[];
 	
 	    static createFrom(source: any = {}) {
-	        return new LoginResponse(source);
+	        return new DeltaContributionsResponse(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.success = source["success"];
-	        this.message = source["message"];
-	        this.userInfo = this.convertValues(source["userInfo"], UserInfo);
+	        this.contributions = this.convertValues(source["contributions"], ContributionDay);
+	        this.totalCommits = source["totalCommits"];
+	        this.warnings = this.convertValues(source["warnings"], ShadingWarning);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -249,74 +360,1421 @@ export namespace main {
 		    return a;
 		}
 	}
-	export class PushRepoRequest {
-	    repoPath: string;
+	export class DripPlan {
+	    name: string;
 	    repoName: string;
 	    branch: string;
-	    isNewRepo: boolean;
-	    isPrivate: boolean;
-	    forcePush: boolean;
-	    commitCount: number;
+	    authorName: string;
+	    authorEmail: string;
+	    language: string;
+	    contributions: ContributionDay[];
+	    completed: Record<string, number>;
+	    enabled: boolean;
+	    createdAt: string;
+	    lastRunAt: string;
+	    lastError: string;
+	    // Go type: SigningOptions
+	    signing?: any;
+	    authors: WeightedIdentity[];
+	    committer?: GitIdentity;
+	    coAuthors: GitIdentity[];
+	    // Go type: CommitMessageOptions
+	    commitMessage?: any;
+	    fileLayout: string;
+	    contentMode: string;
+	    seed: number;
+	    scaffoldDate: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new PushRepoRequest(source);
+	        return new DripPlan(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.repoPath = source["repoPath"];
+	        this.name = source["name"];
 	        this.repoName = source["repoName"];
 	        this.branch = source["branch"];
-	        this.isNewRepo = source["isNewRepo"];
-	        this.isPrivate = source["isPrivate"];
-	        this.forcePush = source["forcePush"];
-	        this.commitCount = source["commitCount"];
+	        this.authorName = source["authorName"];
+	        this.authorEmail = source["authorEmail"];
+	        this.language = source["language"];
+	        this.contributions = this.convertValues(source["contributions"], ContributionDay);
+	        this.completed = source["completed"];
+	        this.enabled = source["enabled"];
+	        this.createdAt = source["createdAt"];
+	        this.lastRunAt = source["lastRunAt"];
+	        this.lastError = source["lastError"];
+	        this.signing = this.convertValues(source["signing"], null);
+	        this.authors = this.convertValues(source["authors"], WeightedIdentity);
+	        this.committer = this.convertValues(source["committer"], GitIdentity);
+	        this.coAuthors = this.convertValues(source["coAuthors"], GitIdentity);
+	        this.commitMessage = this.convertValues(source["commitMessage"], null);
+	        this.fileLayout = source["fileLayout"];
+	        this.contentMode = source["contentMode"];
+	        this.seed = source["seed"];
+	        this.scaffoldDate = source["scaffoldDate"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class PushRepoResponse {
-	    success: boolean;
+	export class DripRunResult {
+	    plan: string;
+	    dates: string[];
+	    commitsCreated: number;
+	    pushed: boolean;
+	    remaining: number;
 	    message: string;
-	    repoUrl: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new PushRepoResponse(source);
+	        return new DripRunResult(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.success = source["success"];
+	        this.plan = source["plan"];
+	        this.dates = source["dates"];
+	        this.commitsCreated = source["commitsCreated"];
+	        this.pushed = source["pushed"];
+	        this.remaining = source["remaining"];
 	        this.message = source["message"];
-	        this.repoUrl = source["repoUrl"];
 	    }
 	}
-	export class SetGitPathRequest {
-	    gitPath: string;
+	export class ExportContributionsRequest {
+	    contributions: ContributionDay[];
+	    format: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new SetGitPathRequest(source);
+	        return new ExportContributionsRequest(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.gitPath = source["gitPath"];
+	        this.contributions = this.convertValues(source["contributions"], ContributionDay);
+	        this.format = source["format"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class SetGitPathResponse {
+	export class ExportContributionsResponse {
 	    success: boolean;
 	    message: string;
-	    version: string;
+	    filePath: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new SetGitPathResponse(source);
+	        return new ExportContributionsResponse(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.success = source["success"];
 	        this.message = source["message"];
-	        this.version = source["version"];
+	        this.filePath = source["filePath"];
 	    }
 	}
+	export class GridRenderOptions {
+	    year: number;
+	    theme: string;
+	    shading: string;
+	    scale: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new GridRenderOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.year = source["year"];
+	        this.theme = source["theme"];
+	        this.shading = source["shading"];
+	        this.scale = source["scale"];
+	    }
+	}
+	export class ExportImageRequest {
+	    contributions: ContributionDay[];
+	    format: string;
+	    options: GridRenderOptions;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExportImageRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.contributions = this.convertValues(source["contributions"], ContributionDay);
+	        this.format = source["format"];
+	        this.options = this.convertValues(source["options"], GridRenderOptions);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LanguageConfig {
+	    language: string;
+	    ratio: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LanguageConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.language = source["language"];
+	        this.ratio = source["ratio"];
+	    }
+	}
+	export class GenerateRepoRequest {
+	    year: number;
+	    githubUsername: string;
+	    githubEmail: string;
+	    repoName: string;
+	    contributions: ContributionDay[];
+	    language: string;
+	    languageConfigs: LanguageConfig[];
+	    multiLanguage: boolean;
+	    deltaMode: boolean;
+	    existingContributions: ContributionDay[];
+	    // Go type: SigningOptions
+	    signing?: any;
+	    authors: WeightedIdentity[];
+	    committer?: GitIdentity;
+	    coAuthors: GitIdentity[];
+	    // Go type: CommitMessageOptions
+	    commitMessage?: any;
+	    fileLayout: string;
+	    contentMode: string;
+	    seed: number;
+	    scaffoldDate: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GenerateRepoRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.year = source["year"];
+	        this.githubUsername = source["githubUsername"];
+	        this.githubEmail = source["githubEmail"];
+	        this.repoName = source["repoName"];
+	        this.contributions = this.convertValues(source["contributions"], ContributionDay);
+	        this.language = source["language"];
+	        this.languageConfigs = this.convertValues(source["languageConfigs"], LanguageConfig);
+	        this.multiLanguage = source["multiLanguage"];
+	        this.deltaMode = source["deltaMode"];
+	        this.existingContributions = this.convertValues(source["existingContributions"], ContributionDay);
+	        this.signing = this.convertValues(source["signing"], null);
+	        this.authors = this.convertValues(source["authors"], WeightedIdentity);
+	        this.committer = this.convertValues(source["committer"], GitIdentity);
+	        this.coAuthors = this.convertValues(source["coAuthors"], GitIdentity);
+	        this.commitMessage = this.convertValues(source["commitMessage"], null);
+	        this.fileLayout = source["fileLayout"];
+	        this.contentMode = source["contentMode"];
+	        this.seed = source["seed"];
+	        this.scaffoldDate = source["scaffoldDate"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GenerateRepoResponse {
+	    repoPath: string;
+	    workspaceName: string;
+	    commitCount: number;
+	    scaffoldCommit: boolean;
+	    author: CommitIdentity;
+	    authorCommits: Record<string, number>;
+	    deltaWarnings?: ShadingWarning[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GenerateRepoResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.repoPath = source["repoPath"];
+	        this.workspaceName = source["workspaceName"];
+	        this.commitCount = source["commitCount"];
+	        this.scaffoldCommit = source["scaffoldCommit"];
+	        this.author = this.convertValues(source["author"], CommitIdentity);
+	        this.authorCommits = source["authorCommits"];
+	        this.deltaWarnings = this.convertValues(source["deltaWarnings"], ShadingWarning);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PlannedFile {
+	    path: string;
+	    size: number;
+	    language: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PlannedFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.size = source["size"];
+	        this.language = source["language"];
+	    }
+	}
+	export class PlannedCommit {
+	    date: string;
+	    timestamp: string;
+	    language: string;
+	    filePath: string;
+	    files?: string[];
+	    message: string;
+	    blobSize: number;
+	    author: GitIdentity;
+	    committer: GitIdentity;
+	    scaffold: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PlannedCommit(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.timestamp = source["timestamp"];
+	        this.language = source["language"];
+	        this.filePath = source["filePath"];
+	        this.files = source["files"];
+	        this.message = source["message"];
+	        this.blobSize = source["blobSize"];
+	        this.author = this.convertValues(source["author"], GitIdentity);
+	        this.committer = this.convertValues(source["committer"], GitIdentity);
+	        this.scaffold = source["scaffold"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GenerationPlan {
+	    repoName: string;
+	    author: CommitIdentity;
+	    commitCount: number;
+	    commits: PlannedCommit[];
+	    files: PlannedFile[];
+	    languageBytes: Record<string, number>;
+	    authorCommits: Record<string, number>;
+	    deltaWarnings?: ShadingWarning[];
+	    scaffoldCommit: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new GenerationPlan(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.repoName = source["repoName"];
+	        this.author = this.convertValues(source["author"], CommitIdentity);
+	        this.commitCount = source["commitCount"];
+	        this.commits = this.convertValues(source["commits"], PlannedCommit);
+	        this.files = this.convertValues(source["files"], PlannedFile);
+	        this.languageBytes = source["languageBytes"];
+	        this.authorCommits = source["authorCommits"];
+	        this.deltaWarnings = this.convertValues(source["deltaWarnings"], ShadingWarning);
+	        this.scaffoldCommit = source["scaffoldCommit"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GitHubRepo {
+	    name: string;
+	    full_name: string;
+	    private: boolean;
+	    html_url: string;
+	    default_branch: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GitHubRepo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.full_name = source["full_name"];
+	        this.private = source["private"];
+	        this.html_url = source["html_url"];
+	        this.default_branch = source["default_branch"];
+	    }
+	}
+	
+	
+	export class ImageToContributionsRequest {
+	    imageData: string;
+	    year: number;
+	    dither: string;
+	    invert: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImageToContributionsRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.imageData = source["imageData"];
+	        this.year = source["year"];
+	        this.dither = source["dither"];
+	        this.invert = source["invert"];
+	    }
+	}
+	export class ImageToContributionsResponse {
+	    contributions: ContributionDay[];
+	    columns: number;
+	    totalCommits: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImageToContributionsResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.contributions = this.convertValues(source["contributions"], ContributionDay);
+	        this.columns = source["columns"];
+	        this.totalCommits = source["totalCommits"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ImportContributionsRequest {
+	    format: string;
+	    mergeStrategy: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportContributionsRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.format = source["format"];
+	        this.mergeStrategy = source["mergeStrategy"];
+	    }
+	}
+	export class ImportRowError {
+	    line: number;
+	    content: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportRowError(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.line = source["line"];
+	        this.content = source["content"];
+	        this.message = source["message"];
+	    }
+	}
+	export class ImportContributionsResponse {
+	    contributions: ContributionDay[];
+	    errors?: ImportRowError[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportContributionsResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.contributions = this.convertValues(source["contributions"], ContributionDay);
+	        this.errors = this.convertValues(source["errors"], ImportRowError);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class ProjectValidationError {
+	    path: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProjectValidationError(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.message = source["message"];
+	    }
+	}
+	export class ProjectGenerationOptions {
+	    language: string;
+	    multiLanguage: boolean;
+	    deltaMode: boolean;
+	    // Go type: SigningOptions
+	    signing?: any;
+	    authors: WeightedIdentity[];
+	    committer?: GitIdentity;
+	    coAuthors: GitIdentity[];
+	    // Go type: CommitMessageOptions
+	    commitMessage?: any;
+	    fileLayout: string;
+	    contentMode: string;
+	    seed: number;
+	    scaffoldDate: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProjectGenerationOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.language = source["language"];
+	        this.multiLanguage = source["multiLanguage"];
+	        this.deltaMode = source["deltaMode"];
+	        this.signing = this.convertValues(source["signing"], null);
+	        this.authors = this.convertValues(source["authors"], WeightedIdentity);
+	        this.committer = this.convertValues(source["committer"], GitIdentity);
+	        this.coAuthors = this.convertValues(source["coAuthors"], GitIdentity);
+	        this.commitMessage = this.convertValues(source["commitMessage"], null);
+	        this.fileLayout = source["fileLayout"];
+	        this.contentMode = source["contentMode"];
+	        this.seed = source["seed"];
+	        this.scaffoldDate = source["scaffoldDate"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ProjectAuthor {
+	    name: string;
+	    email: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProjectAuthor(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.email = source["email"];
+	    }
+	}
+	export class ProjectDocument {
+	    schemaVersion: number;
+	    year: number;
+	    repoName: string;
+	    author: ProjectAuthor;
+	    timezone: string;
+	    contributions: ContributionDay[];
+	    languageConfigs: LanguageConfig[];
+	    options: ProjectGenerationOptions;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProjectDocument(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.schemaVersion = source["schemaVersion"];
+	        this.year = source["year"];
+	        this.repoName = source["repoName"];
+	        this.author = this.convertValues(source["author"], ProjectAuthor);
+	        this.timezone = source["timezone"];
+	        this.contributions = this.convertValues(source["contributions"], ContributionDay);
+	        this.languageConfigs = this.convertValues(source["languageConfigs"], LanguageConfig);
+	        this.options = this.convertValues(source["options"], ProjectGenerationOptions);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LoadProjectResponse {
+	    project?: ProjectDocument;
+	    filePath: string;
+	    migrated: boolean;
+	    errors?: ProjectValidationError[];
+	
+	    static createFrom(source: any = {}) {
+	        return new LoadProjectResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.project = this.convertValues(source["project"], ProjectDocument);
+	        this.filePath = source["filePath"];
+	        this.migrated = source["migrated"];
+	        this.errors = this.convertValues(source["errors"], ProjectValidationError);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class UserInfo {
+	    username: string;
+	    email: string;
+	    token: string;
+	    avatarUrl: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new UserInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.username = source["username"];
+	        this.email = source["email"];
+	        this.token = source["token"];
+	        this.avatarUrl = source["avatarUrl"];
+	    }
+	}
+	export class LoginResponse {
+	    success: boolean;
+	    message: string;
+	    userInfo?: UserInfo;
+	
+	    static createFrom(source: any = {}) {
+	        return new LoginResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.userInfo = this.convertValues(source["userInfo"], UserInfo);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MarqueeFrame {
+	    year: number;
+	    text?: string;
+	    contributions: ContributionDay[];
+	    totalCommits: number;
+	    preview: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MarqueeFrame(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.year = source["year"];
+	        this.text = source["text"];
+	        this.contributions = this.convertValues(source["contributions"], ContributionDay);
+	        this.totalCommits = source["totalCommits"];
+	        this.preview = source["preview"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MarqueeRequest {
+	    startYear: number;
+	    maxYears: number;
+	    theme: string;
+	    text: string;
+	    font: string;
+	    spacing: number;
+	    kerning: boolean;
+	    align: string;
+	    level: number;
+	    imageData: string;
+	    imageColumns: number;
+	    dither: string;
+	    invert: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new MarqueeRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.startYear = source["startYear"];
+	        this.maxYears = source["maxYears"];
+	        this.theme = source["theme"];
+	        this.text = source["text"];
+	        this.font = source["font"];
+	        this.spacing = source["spacing"];
+	        this.kerning = source["kerning"];
+	        this.align = source["align"];
+	        this.level = source["level"];
+	        this.imageData = source["imageData"];
+	        this.imageColumns = source["imageColumns"];
+	        this.dither = source["dither"];
+	        this.invert = source["invert"];
+	    }
+	}
+	export class YearContributions {
+	    year: number;
+	    contributions: ContributionDay[];
+	
+	    static createFrom(source: any = {}) {
+	        return new YearContributions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.year = source["year"];
+	        this.contributions = this.convertValues(source["contributions"], ContributionDay);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MarqueeResponse {
+	    frames: MarqueeFrame[];
+	    years: YearContributions[];
+	    totalCommits: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MarqueeResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.frames = this.convertValues(source["frames"], MarqueeFrame);
+	        this.years = this.convertValues(source["years"], YearContributions);
+	        this.totalCommits = source["totalCommits"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MultiYearRepoRequest {
+	    githubUsername: string;
+	    githubEmail: string;
+	    repoName: string;
+	    startDate: string;
+	    endDate: string;
+	    years: YearContributions[];
+	    contributions: ContributionDay[];
+	    splitByYear: boolean;
+	    language: string;
+	    languageConfigs: LanguageConfig[];
+	    multiLanguage: boolean;
+	    // Go type: SigningOptions
+	    signing?: any;
+	    authors: WeightedIdentity[];
+	    committer?: GitIdentity;
+	    coAuthors: GitIdentity[];
+	    // Go type: CommitMessageOptions
+	    commitMessage?: any;
+	    fileLayout: string;
+	    contentMode: string;
+	    seed: number;
+	    scaffoldDate: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MultiYearRepoRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.githubUsername = source["githubUsername"];
+	        this.githubEmail = source["githubEmail"];
+	        this.repoName = source["repoName"];
+	        this.startDate = source["startDate"];
+	        this.endDate = source["endDate"];
+	        this.years = this.convertValues(source["years"], YearContributions);
+	        this.contributions = this.convertValues(source["contributions"], ContributionDay);
+	        this.splitByYear = source["splitByYear"];
+	        this.language = source["language"];
+	        this.languageConfigs = this.convertValues(source["languageConfigs"], LanguageConfig);
+	        this.multiLanguage = source["multiLanguage"];
+	        this.signing = this.convertValues(source["signing"], null);
+	        this.authors = this.convertValues(source["authors"], WeightedIdentity);
+	        this.committer = this.convertValues(source["committer"], GitIdentity);
+	        this.coAuthors = this.convertValues(source["coAuthors"], GitIdentity);
+	        this.commitMessage = this.convertValues(source["commitMessage"], null);
+	        this.fileLayout = source["fileLayout"];
+	        this.contentMode = source["contentMode"];
+	        this.seed = source["seed"];
+	        this.scaffoldDate = source["scaffoldDate"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class YearRepo {
+	    years: number[];
+	    repoName: string;
+	    repoPath: string;
+	    commitCount: number;
+	    workspaceName: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new YearRepo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.years = source["years"];
+	        this.repoName = source["repoName"];
+	        this.repoPath = source["repoPath"];
+	        this.commitCount = source["commitCount"];
+	        this.workspaceName = source["workspaceName"];
+	    }
+	}
+	export class MultiYearRepoResponse {
+	    repos: YearRepo[];
+	    commitCount: number;
+	    author: CommitIdentity;
+	
+	    static createFrom(source: any = {}) {
+	        return new MultiYearRepoResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.repos = this.convertValues(source["repos"], YearRepo);
+	        this.commitCount = source["commitCount"];
+	        this.author = this.convertValues(source["author"], CommitIdentity);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	
+	
+	
+	export class PushBackup {
+	    repoName: string;
+	    branch: string;
+	    previousSha: string;
+	    pushedSha: string;
+	    backupRef: string;
+	    remoteBackup: boolean;
+	    bundlePath: string;
+	    createdAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PushBackup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.repoName = source["repoName"];
+	        this.branch = source["branch"];
+	        this.previousSha = source["previousSha"];
+	        this.pushedSha = source["pushedSha"];
+	        this.backupRef = source["backupRef"];
+	        this.remoteBackup = source["remoteBackup"];
+	        this.bundlePath = source["bundlePath"];
+	        this.createdAt = source["createdAt"];
+	    }
+	}
+	export class PushRepoRequest {
+	    repoPath: string;
+	    repoName: string;
+	    branch: string;
+	    isNewRepo: boolean;
+	    isPrivate: boolean;
+	    forcePush: boolean;
+	    graftMode: string;
+	    commitCount: number;
+	    setDefaultBranch: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PushRepoRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.repoPath = source["repoPath"];
+	        this.repoName = source["repoName"];
+	        this.branch = source["branch"];
+	        this.isNewRepo = source["isNewRepo"];
+	        this.isPrivate = source["isPrivate"];
+	        this.forcePush = source["forcePush"];
+	        this.graftMode = source["graftMode"];
+	        this.commitCount = source["commitCount"];
+	        this.setDefaultBranch = source["setDefaultBranch"];
+	    }
+	}
+	export class PushRepoResponse {
+	    success: boolean;
+	    message: string;
+	    repoUrl: string;
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PushRepoResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.repoUrl = source["repoUrl"];
+	        this.warnings = source["warnings"];
+	    }
+	}
+	export class SaveProjectRequest {
+	    project: ProjectDocument;
+	
+	    static createFrom(source: any = {}) {
+	        return new SaveProjectRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.project = this.convertValues(source["project"], ProjectDocument);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SaveProjectResponse {
+	    success: boolean;
+	    message: string;
+	    filePath: string;
+	    errors?: ProjectValidationError[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SaveProjectResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.filePath = source["filePath"];
+	        this.errors = this.convertValues(source["errors"], ProjectValidationError);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SetGitPathRequest {
+	    gitPath: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SetGitPathRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.gitPath = source["gitPath"];
+	    }
+	}
+	export class SetGitPathResponse {
+	    success: boolean;
+	    message: string;
+	    version: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SetGitPathResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.version = source["version"];
+	    }
+	}
+	
+	export class SolveLevelCountsRequest {
+	    year: number;
+	    levels: DayLevel[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SolveLevelCountsRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.year = source["year"];
+	        this.levels = this.convertValues(source["levels"], DayLevel);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SolveLevelCountsResponse {
+	    contributions: ContributionDay[];
+	    totalCommits: number;
+	    warnings: ShadingWarning[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SolveLevelCountsResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.contributions = this.convertValues(source["contributions"], ContributionDay);
+	        this.totalCommits = source["totalCommits"];
+	        this.warnings = this.convertValues(source["warnings"], ShadingWarning);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TextBannerPage {
+	    year: number;
+	    text: string;
+	    width: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TextBannerPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.year = source["year"];
+	        this.text = source["text"];
+	        this.width = source["width"];
+	    }
+	}
+	export class TextBannerRequest {
+	    text: string;
+	    year: number;
+	    font: string;
+	    spacing: number;
+	    kerning: boolean;
+	    align: string;
+	    level: number;
+	    scroll: boolean;
+	    maxYears: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TextBannerRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.text = source["text"];
+	        this.year = source["year"];
+	        this.font = source["font"];
+	        this.spacing = source["spacing"];
+	        this.kerning = source["kerning"];
+	        this.align = source["align"];
+	        this.level = source["level"];
+	        this.scroll = source["scroll"];
+	        this.maxYears = source["maxYears"];
+	    }
+	}
+	export class TextBannerResponse {
+	    pages: TextBannerPage[];
+	    years: YearContributions[];
+	    contributions: ContributionDay[];
+	    totalCommits: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TextBannerResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.pages = this.convertValues(source["pages"], TextBannerPage);
+	        this.years = this.convertValues(source["years"], YearContributions);
+	        this.contributions = this.convertValues(source["contributions"], ContributionDay);
+	        this.totalCommits = source["totalCommits"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class WorkspaceRepo {
+	    name: string;
+	    path: string;
+	    year: number;
+	    years?: number[];
+	    commitCount: number;
+	    status: string;
+	    createdAt: string;
+	    lastPushAt: string;
+	    lastPushError: string;
+	    lastPush?: PushRepoRequest;
+	    remoteUrl?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new WorkspaceRepo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.year = source["year"];
+	        this.years = source["years"];
+	        this.commitCount = source["commitCount"];
+	        this.status = source["status"];
+	        this.createdAt = source["createdAt"];
+	        this.lastPushAt = source["lastPushAt"];
+	        this.lastPushError = source["lastPushError"];
+	        this.lastPush = this.convertValues(source["lastPush"], PushRepoRequest);
+	        this.remoteUrl = source["remoteUrl"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
 
 }
 
@@ -2,11 +2,13 @@ package main
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"os"
+	"os/exec"
 	"strings"
 	"time"
 
@@ -38,13 +40,16 @@ type CreateRepoRequest struct {
 
 // PushRepoRequest 包含将本地仓库推送到远程的所有必要信息。
 type PushRepoRequest struct {
-	RepoPath    string `json:"repoPath"`    // 本地临时仓库路径
+	RepoPath    string `json:"repoPath"`    // 本地仓库路径（位于工作区中）
 	RepoName    string `json:"repoName"`    // 远程仓库名
 	Branch      string `json:"branch"`      // 目标推送分支
 	IsNewRepo   bool   `json:"isNewRepo"`   // 是否为新创建的仓库
 	IsPrivate   bool   `json:"isPrivate"`   // (仅新建)是否设为私有
 	ForcePush   bool   `json:"forcePush"`   // 是否强制推送(覆盖远程历史)
+	GraftMode   string `json:"graftMode"`   // 嫁接方式：空(不嫁接)、on-top(接在远程末尾)、merged(合并并入)
 	CommitCount int    `json:"commitCount"` // 提交总数(用于统计显示)
+
+	SetDefaultBranch bool `json:"setDefaultBranch"` // 推送成功后将目标分支设为默认分支
 }
 
 // PushRepoResponse 定义了推送操作的执行结果。
@@ -52,6 +57,8 @@ type PushRepoResponse struct {
 	Success bool   `json:"success"` // 是否成功
 	Message string `json:"message"` // 反馈信息
 	RepoURL string `json:"repoUrl"` // 仓库地址
+
+	Warnings []string `json:"warnings"` // 贡献资格审查发现的问题与提示，不阻止推送
 }
 
 // GetUserRepos 获取当前登录用户在 GitHub 上的所有仓库列表。
@@ -63,7 +70,7 @@ func (a *App) GetUserRepos() ([]GitHubRepo, error) {
 		return nil, fmt.Errorf("未登录")
 	}
 
-	req, err := http.NewRequest("GET", "https://api.github.com/user/repos?per_page=100", nil)
+	req, err := http.NewRequest("GET", githubAPIBase+"/user/repos?per_page=100", nil)
 	if err != nil {
 		LogError("创建请求失败", zap.Error(err))
 		return nil, fmt.Errorf("创建请求失败: %w", err)
@@ -110,7 +117,7 @@ func (a *App) GetRepoBranches(owner, repo string) ([]string, error) {
 		return nil, fmt.Errorf("未登录")
 	}
 
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/branches?per_page=100", owner, repo)
+	url := fmt.Sprintf("%s/repos/%s/%s/branches?per_page=100", githubAPIBase, owner, repo)
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("创建请求失败: %w", err)
@@ -152,7 +159,7 @@ func (a *App) VerifyGitHubToken() error {
 	}
 
 	LogInfo("验证 GitHub token")
-	req, err := http.NewRequest("GET", "https://api.github.com/user", nil)
+	req, err := http.NewRequest("GET", githubAPIBase+"/user", nil)
 	if err != nil {
 		return fmt.Errorf("创建请求失败: %w", err)
 	}
@@ -220,7 +227,7 @@ func (a *App) CreateGitHubRepo(name string, isPrivate bool) (*GitHubRepo, error)
 		return nil, fmt.Errorf("序列化请求失败: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", "https://api.github.com/user/repos", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequest("POST", githubAPIBase+"/user/repos", bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("创建请求失败: %w", err)
 	}
@@ -256,7 +263,7 @@ func (a *App) CreateGitHubRepo(name string, isPrivate bool) (*GitHubRepo, error)
 }
 
 // PushToGitHub 负责将本地生成的提交历史推送到 GitHub 远程仓库。
-// 该方法包含完整的生命周期管理：验证、远程地址配置、多重推送尝试(含强制覆盖逻辑)。
+// 该方法包含完整的生命周期管理：验证、远程地址配置、推送(强制覆盖前会备份远程分支并使用 lease 保护)。
 func (a *App) PushToGitHub(req PushRepoRequest) (*PushRepoResponse, error) {
 	LogInfo("开始推送流程",
 		zap.String("repo_name", req.RepoName),
@@ -284,6 +291,9 @@ func (a *App) PushToGitHub(req PushRepoRequest) (*PushRepoResponse, error) {
 		}
 		repoURL = repo.HTMLURL
 		actualRepoName = repo.Name
+		// 仓库已创建，重试推送时不应再次创建
+		req.IsNewRepo = false
+		req.RepoName = repo.Name
 	} else {
 		actualRepoName = req.RepoName
 		if strings.Contains(actualRepoName, "/") {
@@ -293,13 +303,10 @@ func (a *App) PushToGitHub(req PushRepoRequest) (*PushRepoResponse, error) {
 		}
 	}
 
-	// 3. 配置 Git 远程地址 (使用 Token 注入以实现静默推送)
-	var remoteURL string
-	if strings.Contains(actualRepoName, "/") {
-		remoteURL = fmt.Sprintf("https://%s@github.com/%s.git", a.userInfo.Token, actualRepoName)
-	} else {
-		remoteURL = fmt.Sprintf("https://%s@github.com/%s/%s.git", a.userInfo.Token, a.userInfo.Username, actualRepoName)
-	}
+	fullRepoName := a.fullRepoName(actualRepoName)
+
+	// 3. 配置 Git 远程地址：origin 不含 Token，推送时按命令注入认证头
+	remoteURL := a.githubRemoteURL(actualRepoName)
 
 	if err := a.runGitCommand(req.RepoPath, "remote", "add", "origin", remoteURL); err != nil {
 		a.runGitCommand(req.RepoPath, "remote", "set-url", "origin", remoteURL)
@@ -310,42 +317,258 @@ func (a *App) PushToGitHub(req PushRepoRequest) (*PushRepoResponse, error) {
 		targetBranch = "main"
 	}
 
+	// 预检目标分支：受保护分支拒绝强制推送时尽早失败，而不是等到推送被拒绝
+	runtime.EventsEmit(a.ctx, "push-progress", fmt.Sprintf("正在检查 %s 分支的保护规则...", targetBranch))
+	check, err := a.CheckPushTarget(fullRepoName, targetBranch, req.ForcePush)
+	if err != nil {
+		LogWarn("目标分支预检失败，继续推送", zap.Error(err))
+	} else if len(check.Problems) > 0 {
+		recordPushResult(req, "", strings.Join(check.Problems, "; "))
+		return &PushRepoResponse{Success: false, Message: "无法推送：" + strings.Join(check.Problems, "；")}, nil
+	}
+
+	// 审查生成的提交能否计入贡献图。审查结果只作为提示返回，由界面在推送前展示并让用户确认
+	runtime.EventsEmit(a.ctx, "push-progress", "正在审查提交能否计入贡献图...")
+	warnings := []string{}
+	audit, err := a.AuditContributionEligibility(ContributionAuditRequest{
+		RepoPath:         req.RepoPath,
+		RepoName:         fullRepoName,
+		Branch:           targetBranch,
+		SetDefaultBranch: req.SetDefaultBranch && check != nil && check.CanSetDefault,
+	})
+	if err != nil {
+		LogWarn("贡献资格审查失败，继续推送", zap.Error(err))
+	} else {
+		warnings = append(append(warnings, audit.Problems...), audit.Warnings...)
+	}
+
+	// 嫁接模式：先把生成的历史接到远程分支之上，随后普通推送即可快进
+	if err := validateGraftMode(req.GraftMode); err != nil {
+		return &PushRepoResponse{Success: false, Message: fmt.Sprintf("无效的嫁接方式: %s", req.GraftMode)}, nil
+	}
+	if req.GraftMode != graftModeNone && !req.IsNewRepo {
+		if req.ForcePush {
+			return &PushRepoResponse{Success: false, Message: "嫁接模式会保留远程历史，不能与强制推送同时使用"}, nil
+		}
+		runtime.EventsEmit(a.ctx, "push-progress", fmt.Sprintf("正在将生成的提交嫁接到远程 %s 分支...", targetBranch))
+		if err := a.graftOntoRemote(req.RepoPath, targetBranch, req.GraftMode); err != nil && !errors.Is(err, errRemoteBranchMissing) {
+			LogError("嫁接远程历史失败", zap.Error(err))
+			recordPushResult(req, "", err.Error())
+			return &PushRepoResponse{Success: false, Message: fmt.Sprintf("嫁接远程历史失败: %v", err)}, nil
+		}
+	}
+
 	// 4. 执行推送
 	var pushArgs []string
+	var backup *PushBackup
 	if req.ForcePush {
-		// 强制覆盖模式：使用显式 refspec (本地 main -> 远程 target)
-		pushArgs = []string{"push", "-f", "origin", fmt.Sprintf("main:%s", targetBranch)}
-		runtime.EventsEmit(a.ctx, "push-progress", fmt.Sprintf("🚀 正在彻底覆盖远程 %s 分支...", targetBranch))
+		// 强制覆盖前先备份远程分支，并以备份时观察到的提交作为 lease，避免覆盖他人刚推送的内容
+		runtime.EventsEmit(a.ctx, "push-progress", fmt.Sprintf("正在备份远程 %s 分支...", targetBranch))
+		var err error
+		backup, err = a.backupRemoteBranch(req.RepoPath, fullRepoName, targetBranch)
+		if err != nil {
+			LogError("备份远程分支失败", zap.Error(err))
+			recordPushResult(req, "", err.Error())
+			return &PushRepoResponse{Success: false, Message: fmt.Sprintf("备份远程分支失败，已取消强制推送: %v", err)}, nil
+		}
+		pushArgs = []string{"push", "-u",
+			fmt.Sprintf("--force-with-lease=refs/heads/%s:%s", targetBranch, backup.PreviousSHA),
+			"origin", fmt.Sprintf("main:%s", targetBranch)}
+		runtime.EventsEmit(a.ctx, "push-progress", fmt.Sprintf("🚀 正在覆盖远程 %s 分支...", targetBranch))
 	} else {
 		// 普通推送
 		pushArgs = []string{"push", "-u", "origin", fmt.Sprintf("main:%s", targetBranch)}
 		runtime.EventsEmit(a.ctx, "push-progress", fmt.Sprintf("正在推送到 %s 分支...", targetBranch))
 	}
 
-	if err := a.runGitCommand(req.RepoPath, pushArgs...); err != nil {
-		// 5. 强制推送的灾难恢复逻辑
+	if _, err := a.runGitRemote(req.RepoPath, pushArgs...); err != nil {
+		recordPushResult(req, "", err.Error())
 		if req.ForcePush {
-			LogInfo("初次强推受阻，尝试删除重建策略", zap.String("branch", targetBranch))
-			runtime.EventsEmit(a.ctx, "push-progress", "正在尝试物理删除远程分支以强制重置...")
-			
-			// 尝试删除远程分支后重新推送
-			a.runGitCommand(req.RepoPath, "push", "origin", "--delete", targetBranch)
-			if err := a.runGitCommand(req.RepoPath, "push", "-u", "origin", fmt.Sprintf("main:%s", targetBranch)); err != nil {
-				LogError("所有推送尝试均失败", zap.Error(err))
-				os.RemoveAll(req.RepoPath)
-				return &PushRepoResponse{Success: false, Message: "强制推送失败，请检查分支保护设置"}, nil
-			}
+			LogError("强制推送失败", zap.Error(err))
+			return &PushRepoResponse{Success: false, Message: "强制推送失败：远程分支在备份后发生了变化，或分支受保护不允许强制推送"}, nil
+		}
+		return &PushRepoResponse{Success: false, Message: "推送失败，如果远程已有内容请勾选强制推送"}, nil
+	}
+
+	message := fmt.Sprintf("成功推送 %d 个提交到 %s", req.CommitCount, actualRepoName)
+	if backup != nil {
+		var err error
+		if backup.PushedSHA, err = a.runGitOutput(req.RepoPath, "rev-parse", "main"); err == nil {
+			err = a.saveLastPushBackup(backup)
+		}
+		if err != nil {
+			LogWarn("保存推送备份记录失败", zap.Error(err))
+		} else if backup.PreviousSHA != "" {
+			message += "，原分支内容已备份，可撤销本次推送"
+		}
+	}
+	if req.SetDefaultBranch && check != nil && !check.IsDefault {
+		if err := a.SetDefaultBranch(fullRepoName, targetBranch); err != nil {
+			message += fmt.Sprintf("，但设置默认分支失败: %v", err)
 		} else {
-			os.RemoveAll(req.RepoPath)
-			return &PushRepoResponse{Success: false, Message: "推送失败，如果远程已有内容请勾选强制推送"}, nil
+			message += fmt.Sprintf("，已将 %s 设为默认分支", targetBranch)
 		}
 	}
 
-	// 清理并返回成功
-	os.RemoveAll(req.RepoPath)
+	// 仓库保留在工作区中，由用户决定何时删除
+	recordPushResult(req, repoURL, "")
 	return &PushRepoResponse{
-		Success: true,
-		Message: fmt.Sprintf("成功推送 %d 个提交到 %s", req.CommitCount, actualRepoName),
-		RepoURL: repoURL,
+		Success:  true,
+		Message:  message,
+		RepoURL:  repoURL,
+		Warnings: warnings,
 	}, nil
 }
+
+// githubAPIBase 是 GitHub REST 与 GraphQL API 的地址前缀，测试中可替换为本地服务。
+var githubAPIBase = "https://api.github.com"
+
+// githubGitBase 是 Git 远程地址的前缀，测试中可替换为本地目录。
+var githubGitBase = "https://github.com"
+
+// githubRemoteURL 返回不含凭据的 HTTPS 远程地址，repoName 可以是 "repo" 或 "owner/repo"。
+// Token 不写入仓库配置，访问远程时由 gitAuthEnv 按命令注入。
+func (a *App) githubRemoteURL(repoName string) string {
+	return fmt.Sprintf("%s/%s.git", githubGitBase, a.fullRepoName(repoName))
+}
+
+// gitAuthEnv 通过 GIT_CONFIG_* 环境变量为单条 git 命令注入 GitHub 认证头，
+// Token 既不写入 .git/config，也不出现在命令行参数中。
+func (a *App) gitAuthEnv() []string {
+	env := []string{"GIT_TERMINAL_PROMPT=0"}
+	if a.userInfo == nil || a.userInfo.Token == "" {
+		return env
+	}
+	credential := base64.StdEncoding.EncodeToString([]byte(a.userInfo.Token + ":x-oauth-basic"))
+	return append(env,
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=http."+githubGitBase+"/.extraHeader",
+		"GIT_CONFIG_VALUE_0=Authorization: Basic "+credential)
+}
+
+// remoteBranchExists 通过 ls-remote --exit-code 查询远程分支是否存在。
+// 退出码 2 表示分支不存在；网络、认证等其他失败作为错误返回，不会被误当作分支不存在。
+func (a *App) remoteBranchExists(repoPath, branch string) (bool, error) {
+	_, err := a.runGitRemote(repoPath, "ls-remote", "--exit-code", "origin", "refs/heads/"+branch)
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == 2:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// runGitRemote 执行需要访问远程仓库的 git 命令（fetch、push、ls-remote），返回去掉首尾空白的标准输出。
+func (a *App) runGitRemote(dir string, args ...string) (string, error) {
+	out, err := a.runGitInput(dir, a.gitAuthEnv(), nil, args...)
+	return strings.TrimSpace(out), err
+}
+
+// contributionCalendarQuery 是获取用户贡献日历的 GraphQL 查询语句。
+const contributionCalendarQuery = `query($from: DateTime!, $to: DateTime!) {
+  viewer {
+    contributionsCollection(from: $from, to: $to) {
+      contributionCalendar {
+        weeks {
+          contributionDays {
+            date
+            contributionCount
+          }
+        }
+      }
+    }
+  }
+}`
+
+// FetchContributionCalendar 通过 GitHub GraphQL API 获取当前登录用户指定年份的贡献日历。
+func (a *App) FetchContributionCalendar(year int) ([]ContributionDay, error) {
+	LogInfo("获取用户贡献日历", zap.Int("year", year))
+
+	if a.userInfo == nil || a.userInfo.Token == "" {
+		LogError("获取贡献日历失败：用户未登录")
+		return nil, fmt.Errorf("未登录")
+	}
+	if year <= 0 {
+		return nil, fmt.Errorf("无效的年份: %d", year)
+	}
+
+	payload := map[string]interface{}{
+		"query": contributionCalendarQuery,
+		"variables": map[string]string{
+			"from": fmt.Sprintf("%04d-01-01T00:00:00Z", year),
+			"to":   fmt.Sprintf("%04d-12-31T23:59:59Z", year),
+		},
+	}
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("序列化请求失败: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", githubAPIBase+"/graphql", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+a.userInfo.Token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "GreenWall-App")
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		LogError("请求 GitHub GraphQL API 失败", zap.Error(err))
+		return nil, fmt.Errorf("请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		LogError("GitHub GraphQL API 返回错误", zap.Int("status_code", resp.StatusCode), zap.String("response", string(body)))
+		return nil, fmt.Errorf("GitHub API 返回错误 %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Data struct {
+			Viewer struct {
+				ContributionsCollection struct {
+					ContributionCalendar struct {
+						Weeks []struct {
+							ContributionDays []struct {
+								Date              string `json:"date"`
+								ContributionCount int    `json:"contributionCount"`
+							} `json:"contributionDays"`
+						} `json:"weeks"`
+					} `json:"contributionCalendar"`
+				} `json:"contributionsCollection"`
+			} `json:"viewer"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		LogError("解析贡献日历失败", zap.Error(err))
+		return nil, fmt.Errorf("解析贡献日历失败: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		LogError("GitHub GraphQL API 返回错误", zap.String("message", result.Errors[0].Message))
+		return nil, fmt.Errorf("GitHub API 返回错误: %s", result.Errors[0].Message)
+	}
+
+	var days []ContributionDay
+	for _, week := range result.Data.Viewer.ContributionsCollection.ContributionCalendar.Weeks {
+		for _, d := range week.ContributionDays {
+			days = append(days, ContributionDay{Date: d.Date, Count: d.ContributionCount})
+		}
+	}
+
+	LogInfo("获取贡献日历成功", zap.Int("days", len(days)))
+	return days, nil
+}
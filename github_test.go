@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newBareRemote 将 githubGitBase 指向临时目录，并创建 owner/repo 对应的裸仓库，返回其路径。
+func newBareRemote(t *testing.T, a *App, owner, repo string) string {
+	t.Helper()
+	base := t.TempDir()
+	old := githubGitBase
+	githubGitBase = base
+	t.Cleanup(func() { githubGitBase = old })
+
+	bare := filepath.Join(base, owner, repo+".git")
+	if err := os.MkdirAll(bare, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.runGitCommand(bare, "init", "--bare", "-b", "main"); err != nil {
+		t.Fatal(err)
+	}
+	return bare
+}
+
+// commitFile 在 repoPath 中写入文件并提交，返回新提交的 SHA。
+func commitFile(t *testing.T, a *App, repoPath, name, content string) string {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(repoPath, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.runGitCommand(repoPath, "add", name); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.runGitCommand(repoPath, "-c", "user.name=tester", "-c", "user.email=tester@example.com",
+		"commit", "-q", "-m", "update "+name); err != nil {
+		t.Fatal(err)
+	}
+	sha, err := a.runGitOutput(repoPath, "rev-parse", "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sha
+}
+
+// Token 只通过环境变量按命令注入，origin 与 .git/config 中都不含 Token。
+func TestGitRemoteKeepsTokenOutOfConfig(t *testing.T) {
+	a := newTestApp(t)
+	a.userInfo = &UserInfo{Username: "octo", Token: "gho_secret"}
+	bare := newBareRemote(t, a, "octo", "wall")
+
+	repoPath := t.TempDir()
+	if err := a.runGitCommand(repoPath, "init", "-q", "-b", "main"); err != nil {
+		t.Fatal(err)
+	}
+	sha := commitFile(t, a, repoPath, "a.txt", "a\n")
+	if err := a.runGitCommand(repoPath, "remote", "add", "origin", a.githubRemoteURL("wall")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.runGitRemote(repoPath, "push", "origin", "main"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := a.runGitOutput(bare, "rev-parse", "main"); err != nil || got != sha {
+		t.Fatalf("remote main = %q, %v; want %s", got, err, sha)
+	}
+
+	config, err := os.ReadFile(filepath.Join(repoPath, ".git", "config"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(config), "gho_secret") {
+		t.Errorf(".git/config contains the token:\n%s", config)
+	}
+
+	env := strings.Join(a.gitAuthEnv(), "\n")
+	credential := base64.StdEncoding.EncodeToString([]byte("gho_secret:x-oauth-basic"))
+	if !strings.Contains(env, "Authorization: Basic "+credential) || strings.Contains(env, "gho_secret") {
+		t.Errorf("auth env = %q", env)
+	}
+}
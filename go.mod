@@ -6,6 +6,7 @@ require (
 	github.com/pkg/browser v0.0.0-20240102092130-5ac0b6a4141c
 	github.com/wailsapp/wails/v2 v2.10.2
 	go.uber.org/zap v1.27.0
+	golang.org/x/image v0.24.0
 )
 
 require (
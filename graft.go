@@ -0,0 +1,216 @@
+// graft.go 实现“嫁接”推送：在不强制推送的前提下，把生成的提交接到远程分支现有历史之上，
+// 或以合并提交的方式并入，保留仓库中已有的真实工作。
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// 嫁接方式。
+const (
+	graftModeNone   = ""       // 不嫁接，直接推送生成的历史
+	graftModeOnTop  = "on-top" // 将生成的提交依次接在远程分支末尾
+	graftModeMerged = "merged" // 保留生成的独立历史，通过合并提交并入远程分支
+)
+
+// errRemoteBranchMissing 表示远程目标分支不存在，无需嫁接即可直接推送。
+var errRemoteBranchMissing = errors.New("remote branch does not exist")
+
+// validateGraftMode 校验嫁接方式。
+func validateGraftMode(mode string) error {
+	switch mode {
+	case graftModeNone, graftModeOnTop, graftModeMerged:
+		return nil
+	default:
+		return fmt.Errorf("unsupported graft mode %q", mode)
+	}
+}
+
+// graftOntoRemote 获取远程分支并将本地 main 分支改写为远程分支的后代，之后可以普通推送。
+// 远程分支中已存在的文件不会被生成的提交覆盖。
+func (a *App) graftOntoRemote(repoPath, branch, mode string) error {
+	exists, err := a.remoteBranchExists(repoPath, branch)
+	if err != nil {
+		return fmt.Errorf("query remote branch %s: %w", branch, err)
+	}
+	if !exists {
+		LogInfo("远程分支不存在，跳过嫁接", zap.String("branch", branch))
+		return errRemoteBranchMissing
+	}
+	if _, err := a.runGitRemote(repoPath, "fetch", "origin", branch); err != nil {
+		return fmt.Errorf("fetch origin/%s: %w", branch, err)
+	}
+	tip, err := a.runGitOutput(repoPath, "rev-parse", "FETCH_HEAD")
+	if err != nil {
+		return err
+	}
+	// 重试推送时本地分支可能已经嫁接过
+	if err := a.runGitCommand(repoPath, "merge-base", "--is-ancestor", tip, "main"); err == nil {
+		LogInfo("本地分支已包含远程历史，无需再次嫁接", zap.String("tip", tip))
+		return nil
+	}
+
+	LogInfo("开始嫁接生成的历史", zap.String("branch", branch), zap.String("tip", tip), zap.String("mode", mode))
+	switch mode {
+	case graftModeOnTop:
+		return a.graftOnTop(repoPath, tip)
+	case graftModeMerged:
+		return a.graftMerged(repoPath, tip, branch)
+	default:
+		return fmt.Errorf("unsupported graft mode %q", mode)
+	}
+}
+
+// graftOnTop 通过 fast-export / fast-import 重放生成的提交：第一个提交的父提交改为远程分支末端，
+// 并丢弃对远程已有文件的修改，作者与提交时间保持不变。
+func (a *App) graftOnTop(repoPath, tip string) error {
+	// 路径列表与导出流都按原样读取，不能去除首尾空白
+	listing, err := a.runGitInput(repoPath, nil, nil, "ls-tree", "-r", "--name-only", "-z", tip)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool)
+	for _, path := range strings.Split(listing, "\x00") {
+		if path != "" {
+			existing[path] = true
+		}
+	}
+
+	// fast-export 的输出经改写后通过管道直接送入 fast-import
+	export := exec.Command(a.getGitCommand(), "fast-export", "--signed-tags=strip", "refs/heads/main")
+	export.Dir = repoPath
+	configureCommand(export, true)
+	var exportStderr bytes.Buffer
+	export.Stderr = &exportStderr
+	exported, err := export.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := export.Start(); err != nil {
+		return fmt.Errorf("start fast-export: %w", err)
+	}
+	pr, pw := io.Pipe()
+	rewriteErr := make(chan error, 1)
+	go func() {
+		err := rewriteGraftStream(exported, pw, tip, existing)
+		pw.CloseWithError(err)
+		// fast-import 提前退出时读完剩余输出，避免 fast-export 阻塞
+		io.Copy(io.Discard, exported)
+		rewriteErr <- err
+	}()
+	importErr := a.runGitFastImport(repoPath, pr, "--force")
+	pr.Close()
+	err = <-rewriteErr
+	if waitErr := export.Wait(); waitErr != nil {
+		return fmt.Errorf("git fast-export: %w (%s)", waitErr, strings.TrimSpace(exportStderr.String()))
+	}
+	if err != nil && !errors.Is(err, io.ErrClosedPipe) {
+		return fmt.Errorf("rewrite history: %w", err)
+	}
+	if importErr != nil {
+		return fmt.Errorf("fast-import failed: %w", importErr)
+	}
+	// 重放会丢失签名，生成时启用了签名则重新签名
+	if a.signingEnabled(repoPath) {
+		if _, err := a.signCommits(repoPath, tip, "refs/heads/main"); err != nil {
+			return err
+		}
+	}
+	return a.runGitCommand(repoPath, "checkout", "-f", "main")
+}
+
+// rewriteGraftStream 改写 fast-export 输出：为根提交添加 from，并删除修改已有文件的 M 指令。
+func rewriteGraftStream(r io.Reader, w io.Writer, tip string, existing map[string]bool) error {
+	br := bufio.NewReader(r)
+	bw := bufio.NewWriter(w)
+	inCommit, hasParent := false, false
+	for {
+		line, err := br.ReadString('\n')
+		if err == io.EOF && line == "" {
+			break
+		}
+		if err != nil && err != io.EOF {
+			return err
+		}
+		trimmed := strings.TrimSuffix(line, "\n")
+
+		switch {
+		case strings.HasPrefix(trimmed, "commit "):
+			inCommit, hasParent = true, false
+		case strings.HasPrefix(trimmed, "blob"), strings.HasPrefix(trimmed, "reset "):
+			inCommit = false
+		case inCommit && strings.HasPrefix(trimmed, "from "):
+			hasParent = true
+		case inCommit && strings.HasPrefix(trimmed, "M "):
+			if fields := strings.SplitN(trimmed, " ", 4); len(fields) == 4 && existing[unquoteGitPath(fields[3])] {
+				continue
+			}
+		}
+
+		if _, err := bw.WriteString(line); err != nil {
+			return err
+		}
+		if !strings.HasPrefix(trimmed, "data ") {
+			continue
+		}
+
+		// 原样复制 data 块，提交说明之后紧接的 from 行决定父提交
+		size, convErr := strconv.Atoi(strings.TrimPrefix(trimmed, "data "))
+		if convErr != nil {
+			return fmt.Errorf("unsupported data header %q", trimmed)
+		}
+		if _, err := io.CopyN(bw, br, int64(size)); err != nil {
+			return err
+		}
+		if inCommit {
+			if next, _ := br.Peek(5); string(next) == "from " {
+				hasParent = true
+			}
+			if !hasParent {
+				if _, err := fmt.Fprintf(bw, "from %s\n", tip); err != nil {
+					return err
+				}
+				hasParent = true
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// unquoteGitPath 还原 fast-export 中带引号转义的路径。
+func unquoteGitPath(path string) string {
+	if strings.HasPrefix(path, `"`) {
+		if unquoted, err := strconv.Unquote(path); err == nil {
+			return unquoted
+		}
+	}
+	return path
+}
+
+// graftMerged 以远程分支末端为第一父提交，将生成的历史作为第二父提交合并进来。
+// 冲突时保留远程的内容，生成的历史本身保持不变。
+func (a *App) graftMerged(repoPath, tip, branch string) error {
+	const tempBranch = "greenwall-graft"
+	if err := a.runGitCommand(repoPath, "checkout", "-f", "-B", tempBranch, tip); err != nil {
+		return err
+	}
+	msg := fmt.Sprintf("Merge generated contribution history into %s", branch)
+	if err := a.runGitCommand(repoPath, "merge", "--allow-unrelated-histories", "--no-edit", "-X", "ours", "-m", msg, "main"); err != nil {
+		_ = a.runGitCommand(repoPath, "merge", "--abort")
+		_ = a.runGitCommand(repoPath, "checkout", "-f", "main")
+		return err
+	}
+	if err := a.runGitCommand(repoPath, "checkout", "-f", "-B", "main", tempBranch); err != nil {
+		return err
+	}
+	return a.runGitCommand(repoPath, "branch", "-D", tempBranch)
+}
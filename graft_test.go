@@ -0,0 +1,203 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fastExportData 返回 fast-export 格式的 data 块。
+func fastExportData(content string) string {
+	return fmt.Sprintf("data %d\n%s", len(content), content)
+}
+
+func TestRewriteGraftStream(t *testing.T) {
+	const tip = "1111111111111111111111111111111111111111"
+	// data 块中出现的 commit、M、from 行属于文件内容与提交说明，不能被当作指令改写
+	blob := "commit refs/heads/other\nM 100644 :9 README.md\n"
+	message := "Paint the wall\n\nM 100644 :1 README.md\nfrom nowhere\n"
+	author := "author A <a@example.com> 1704153600 +0000\ncommitter A <a@example.com> 1704153600 +0000\n"
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name: "root commit gets the remote tip as parent",
+			input: "commit refs/heads/main\nmark :1\n" + author + fastExportData("first\n") +
+				"M 100644 inline new.txt\n\n",
+			want: "commit refs/heads/main\nmark :1\n" + author + fastExportData("first\n") + "from " + tip + "\n" +
+				"M 100644 inline new.txt\n\n",
+		},
+		{
+			name: "multiple commits and quoted paths",
+			input: "blob\nmark :1\n" + fastExportData(blob) + "\n" +
+				"commit refs/heads/main\nmark :2\n" + author + fastExportData(message) +
+				"M 100644 :1 README.md\n" +
+				"M 100644 :1 \"sp\\303\\251cial.txt\"\n" +
+				"M 100644 :1 new file.txt\n\n" +
+				"commit refs/heads/main\nmark :3\n" + author + fastExportData("second\n") +
+				"from :2\n" +
+				"M 100644 :1 README.md\n" +
+				"M 100644 :1 \"tab\\there.txt\"\n\n",
+			want: "blob\nmark :1\n" + fastExportData(blob) + "\n" +
+				"commit refs/heads/main\nmark :2\n" + author + fastExportData(message) + "from " + tip + "\n" +
+				"M 100644 :1 new file.txt\n\n" +
+				"commit refs/heads/main\nmark :3\n" + author + fastExportData("second\n") +
+				"from :2\n" +
+				"M 100644 :1 \"tab\\there.txt\"\n\n",
+		},
+		{
+			name:  "commit that already has a parent",
+			input: "reset refs/heads/main\nfrom :5\n\ncommit refs/heads/main\nmark :6\n" + author + fastExportData("x") + "from :5\n\n",
+			want:  "reset refs/heads/main\nfrom :5\n\ncommit refs/heads/main\nmark :6\n" + author + fastExportData("x") + "from :5\n\n",
+		},
+	}
+	existing := map[string]bool{"README.md": true, "spécial.txt": true}
+	for _, tt := range tests {
+		var out strings.Builder
+		if err := rewriteGraftStream(strings.NewReader(tt.input), &out, tip, existing); err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if out.String() != tt.want {
+			t.Errorf("%s:\ngot  %q\nwant %q", tt.name, out.String(), tt.want)
+		}
+	}
+
+	for _, input := range []string{
+		"commit refs/heads/main\ndata x\n",
+		"commit refs/heads/main\ndata 100\nshort\n",
+	} {
+		if err := rewriteGraftStream(strings.NewReader(input), &strings.Builder{}, tip, existing); err == nil {
+			t.Errorf("rewrite %q: expected error", input)
+		}
+	}
+}
+
+// newGraftFixture 生成一个仓库，并创建一个已有 README.md 与 notes.txt 的远程 main 分支，返回仓库路径、
+// 生成的 main 与远程末端。
+func newGraftFixture(t *testing.T, a *App) (repoPath, generated, tip string) {
+	t.Helper()
+	a.userInfo = &UserInfo{Username: "octo", Token: "gho_secret"}
+	bare := newBareRemote(t, a, "octo", "graft")
+	seed := t.TempDir()
+	if err := a.runGitCommand(seed, "init", "-q", "-b", "main"); err != nil {
+		t.Fatal(err)
+	}
+	commitFile(t, a, seed, "README.md", "remote readme\n")
+	tip = commitFile(t, a, seed, "notes.txt", "real work\n")
+	if err := a.runGitCommand(seed, "push", "-q", bare, "main"); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := a.GenerateRepo(GenerateRepoRequest{
+		Year:           2024,
+		GithubUsername: "tester",
+		GithubEmail:    "tester@example.com",
+		RepoName:       "graft",
+		Language:       "go",
+		Contributions: []ContributionDay{
+			{Date: "2024-01-02", Count: 2},
+			{Date: "2024-03-04", Count: 1},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.runGitCommand(resp.RepoPath, "remote", "add", "origin", a.githubRemoteURL("graft")); err != nil {
+		t.Fatal(err)
+	}
+	if generated, err = a.runGitOutput(resp.RepoPath, "rev-parse", "main"); err != nil {
+		t.Fatal(err)
+	}
+	return resp.RepoPath, generated, tip
+}
+
+// checkGrafted 检查远程已有的文件保持不变，并且嫁接后的分支可以快进推送。
+func checkGrafted(t *testing.T, a *App, repoPath, tip string) {
+	t.Helper()
+	if err := a.runGitCommand(repoPath, "merge-base", "--is-ancestor", tip, "main"); err != nil {
+		t.Fatalf("remote tip %s is not an ancestor of main", tip)
+	}
+	for file, want := range map[string]string{"README.md": "remote readme", "notes.txt": "real work"} {
+		if got, err := a.runGitOutput(repoPath, "show", "main:"+file); err != nil || got != want {
+			t.Errorf("%s = %q, %v; want %q", file, got, err, want)
+		}
+	}
+	if _, err := a.runGitOutput(repoPath, "show", "main:activity.go"); err != nil {
+		t.Errorf("generated file is missing: %v", err)
+	}
+	if _, err := a.runGitRemote(repoPath, "push", "origin", "main:main"); err != nil {
+		t.Fatalf("fast-forward push: %v", err)
+	}
+}
+
+func TestGraftOnTop(t *testing.T) {
+	a := newTestApp(t)
+	repoPath, generated, tip := newGraftFixture(t, a)
+	before, err := a.runGitOutput(repoPath, "log", "--format=%an %ae %ad %s", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.graftOntoRemote(repoPath, "main", graftModeOnTop); err != nil {
+		t.Fatal(err)
+	}
+	after, err := a.runGitOutput(repoPath, "log", "--format=%an %ae %ad %s", tip+"..main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after != before {
+		t.Errorf("replayed commits differ:\n%s\nwant\n%s", after, before)
+	}
+	if head, _ := a.runGitOutput(repoPath, "rev-parse", "main"); head == generated {
+		t.Error("main was not rewritten")
+	}
+	checkGrafted(t, a, repoPath, tip)
+
+	// 重试推送时已嫁接过的分支保持不变
+	head, _ := a.runGitOutput(repoPath, "rev-parse", "main")
+	if err := a.graftOntoRemote(repoPath, "main", graftModeOnTop); err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := a.runGitOutput(repoPath, "rev-parse", "main"); again != head {
+		t.Errorf("second graft moved main from %s to %s", head, again)
+	}
+}
+
+func TestGraftMerged(t *testing.T) {
+	a := newTestApp(t)
+	repoPath, generated, tip := newGraftFixture(t, a)
+
+	if err := a.graftOntoRemote(repoPath, "main", graftModeMerged); err != nil {
+		t.Fatal(err)
+	}
+	parents, err := a.runGitOutput(repoPath, "rev-list", "--parents", "-n", "1", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields := strings.Fields(parents); len(fields) != 3 || fields[1] != tip || fields[2] != generated {
+		t.Fatalf("merge parents = %v, want %s and %s", fields[1:], tip, generated)
+	}
+	checkGrafted(t, a, repoPath, tip)
+}
+
+// 远程分支不存在时无需嫁接；远程仓库无法访问时返回错误，而不是当作分支不存在。
+func TestGraftOntoRemoteMissingBranch(t *testing.T) {
+	a := newTestApp(t)
+	repoPath, _, _ := newGraftFixture(t, a)
+	if err := a.graftOntoRemote(repoPath, "feature", graftModeOnTop); !errors.Is(err, errRemoteBranchMissing) {
+		t.Fatalf("missing branch: error = %v", err)
+	}
+	if err := os.RemoveAll(filepath.Join(githubGitBase, "octo", "graft.git")); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.graftOntoRemote(repoPath, "main", graftModeOnTop); err == nil || errors.Is(err, errRemoteBranchMissing) {
+		t.Fatalf("unreachable remote: error = %v", err)
+	}
+}
@@ -0,0 +1,366 @@
+// grid_render.go 在后端将贡献数据渲染为 GitHub 风格的贡献图（SVG / PNG），用于导出图片和命令行预览。
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"go.uber.org/zap"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// 贡献图的布局参数（单位：像素），与 GitHub 个人主页上的日历保持相近比例。
+const (
+	gridCellSize    = 10 // 格子边长
+	gridCellGap     = 3  // 格子间距
+	gridLeftMargin  = 32 // 左侧星期标签区域宽度
+	gridTopMargin   = 20 // 顶部月份标签区域高度
+	gridPadding     = 8  // 四周留白
+	gridMaxPNGScale = 8  // PNG 最大放大倍数
+)
+
+// 渲染主题与着色方式。
+const (
+	gridThemeLight = "light"
+	gridThemeDark  = "dark"
+
+	gridShadingDrawing = "drawing" // 按前端画笔的固定阈值着色，与编辑器显示一致
+	gridShadingGitHub  = "github"  // 按 GitHub 的分位数规则着色，预览推送后的实际效果
+)
+
+// gridPalette 描述一套配色，色阶颜色需与 frontend/src/constants.ts 中的 GITHUB_COLORS 保持一致。
+type gridPalette struct {
+	background string
+	text       string
+	levels     [maxContributionLevel + 1]string
+}
+
+var gridPalettes = map[string]gridPalette{
+	gridThemeLight: {
+		background: "#ffffff",
+		text:       "#656d76",
+		levels:     [maxContributionLevel + 1]string{"#ebedf0", "#9be9a8", "#40c463", "#30a14e", "#216e39"},
+	},
+	gridThemeDark: {
+		background: "#0d1117",
+		text:       "#7d8590",
+		levels:     [maxContributionLevel + 1]string{"#161b22", "#0e4429", "#006d32", "#26a641", "#39d353"},
+	},
+}
+
+var gridMonthLabels = [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+
+// gridWeekdayLabels 与 GitHub 一致，只标注周一、周三、周五。
+var gridWeekdayLabels = map[time.Weekday]string{time.Monday: "Mon", time.Wednesday: "Wed", time.Friday: "Fri"}
+
+// GridRenderOptions 控制贡献图的渲染方式。
+type GridRenderOptions struct {
+	Year    int    `json:"year"`    // 渲染的年份，为 0 时根据数据推断
+	Theme   string `json:"theme"`   // light 或 dark，默认 light
+	Shading string `json:"shading"` // drawing 或 github，默认 drawing
+	Scale   int    `json:"scale"`   // PNG 放大倍数 (1-8)，默认 1
+}
+
+// gridCell 是布局完成后的单个格子。
+type gridCell struct {
+	date  string
+	count int
+	level int
+	x, y  int
+}
+
+// gridLabel 是布局完成后的一个文字标签，(x, y) 为文字基线左端。
+type gridLabel struct {
+	text string
+	x, y int
+}
+
+// gridLayout 是与输出格式无关的贡献图布局结果。
+type gridLayout struct {
+	width, height int
+	palette       gridPalette
+	cells         []gridCell
+	labels        []gridLabel
+}
+
+// yearGridShape 返回某年在贡献图中的形状：1 月 1 日所在的行（周日为 0）以及总列数（周数）。
+// 第 i 天 (从 0 开始) 位于第 (i+offset)/7 列、第 (i+offset)%7 行。
+func yearGridShape(year int) (offset, weeks int) {
+	first := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	offset = int(first.Weekday())
+	days := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	return offset, (days + offset + 6) / 7
+}
+
+// layoutContributionGrid 计算贡献图中每个格子与标签的位置。
+// 列为自然周（周日开始），行为星期几，与 GitHub 的日历布局一致。
+func layoutContributionGrid(contributions []ContributionDay, opts GridRenderOptions) (*gridLayout, error) {
+	year := opts.Year
+	if year <= 0 {
+		year = inferContributionYear(contributions)
+	}
+	if year <= 0 {
+		year = time.Now().Year()
+	}
+
+	theme := strings.ToLower(strings.TrimSpace(opts.Theme))
+	if theme == "" {
+		theme = gridThemeLight
+	}
+	palette, ok := gridPalettes[theme]
+	if !ok {
+		return nil, fmt.Errorf("unsupported theme %q", opts.Theme)
+	}
+
+	counts := make(map[string]int, len(contributions))
+	for _, c := range contributions {
+		if c.Count < 0 {
+			return nil, fmt.Errorf("invalid contribution count for %s: %d", c.Date, c.Count)
+		}
+		counts[c.Date] += c.Count
+	}
+
+	dates := datesOfYear(year)
+	levels := make(map[string]int, len(dates))
+	switch strings.ToLower(strings.TrimSpace(opts.Shading)) {
+	case "", gridShadingDrawing:
+		for _, d := range dates {
+			levels[d] = levelFromDrawingCount(counts[d])
+		}
+	case gridShadingGitHub:
+		yearCounts := make(map[string]int, len(dates))
+		for _, d := range dates {
+			yearCounts[d] = counts[d]
+		}
+		levels = githubLevels(yearCounts)
+	default:
+		return nil, fmt.Errorf("unsupported shading %q", opts.Shading)
+	}
+
+	step := gridCellSize + gridCellGap
+	originX := gridPadding + gridLeftMargin
+	originY := gridPadding + gridTopMargin
+	first := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	offset, weeks := yearGridShape(year)
+
+	layout := &gridLayout{palette: palette}
+	lastMonth := time.Month(0)
+	for i, d := range dates {
+		week := (i + offset) / 7
+		weekday := (i + offset) % 7
+		layout.cells = append(layout.cells, gridCell{
+			date:  d,
+			count: counts[d],
+			level: levels[d],
+			x:     originX + week*step,
+			y:     originY + weekday*step,
+		})
+
+		// 月份标签放在该月第一天所在的列；跨月的首列若离上一个标签太近则顺延一列
+		t := first.AddDate(0, 0, i)
+		if t.Month() != lastMonth {
+			lastMonth = t.Month()
+			col := week
+			if weekday > 0 && i > 0 {
+				col++
+			}
+			layout.labels = append(layout.labels, gridLabel{
+				text: gridMonthLabels[t.Month()-1],
+				x:    originX + col*step,
+				y:    gridPadding + gridTopMargin - 8,
+			})
+		}
+	}
+
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if text, ok := gridWeekdayLabels[weekday]; ok {
+			layout.labels = append(layout.labels, gridLabel{
+				text: text,
+				x:    gridPadding,
+				y:    originY + int(weekday)*step + gridCellSize - 1,
+			})
+		}
+	}
+
+	layout.width = originX + weeks*step - gridCellGap + gridPadding
+	layout.height = originY + 7*step - gridCellGap + gridPadding
+	return layout, nil
+}
+
+// renderContributionSVG 将贡献数据渲染为 SVG 文档。
+func renderContributionSVG(contributions []ContributionDay, opts GridRenderOptions) ([]byte, error) {
+	layout, err := layoutContributionGrid(contributions, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n",
+		layout.width, layout.height, layout.width, layout.height)
+	fmt.Fprintf(&buf, "  <rect width=\"100%%\" height=\"100%%\" fill=\"%s\"/>\n", layout.palette.background)
+	fmt.Fprintf(&buf, "  <g font-family=\"-apple-system, BlinkMacSystemFont, 'Segoe UI', Helvetica, Arial, sans-serif\" font-size=\"9\" fill=\"%s\">\n", layout.palette.text)
+	for _, l := range layout.labels {
+		fmt.Fprintf(&buf, "    <text x=\"%d\" y=\"%d\">%s</text>\n", l.x, l.y, l.text)
+	}
+	buf.WriteString("  </g>\n")
+	buf.WriteString("  <g>\n")
+	for _, c := range layout.cells {
+		fmt.Fprintf(&buf, "    <rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" rx=\"2\" ry=\"2\" fill=\"%s\" data-date=\"%s\" data-count=\"%d\" data-level=\"%d\"><title>%s: %d</title></rect>\n",
+			c.x, c.y, gridCellSize, gridCellSize, layout.palette.levels[c.level], c.date, c.count, c.level, c.date, c.count)
+	}
+	buf.WriteString("  </g>\n")
+	buf.WriteString("</svg>\n")
+	return buf.Bytes(), nil
+}
+
+// renderContributionPNG 将贡献数据渲染为 PNG 图片，标签使用内置的位图字体绘制。
+func renderContributionPNG(contributions []ContributionDay, opts GridRenderOptions) ([]byte, error) {
+	layout, err := layoutContributionGrid(contributions, opts)
+	if err != nil {
+		return nil, err
+	}
+	scale := opts.Scale
+	if scale <= 0 {
+		scale = 1
+	}
+	if scale > gridMaxPNGScale {
+		return nil, fmt.Errorf("scale must be between 1 and %d", gridMaxPNGScale)
+	}
+
+	background, err := parseHexColor(layout.palette.background)
+	if err != nil {
+		return nil, err
+	}
+	text, err := parseHexColor(layout.palette.text)
+	if err != nil {
+		return nil, err
+	}
+	var levels [maxContributionLevel + 1]color.RGBA
+	for i, hex := range layout.palette.levels {
+		if levels[i], err = parseHexColor(hex); err != nil {
+			return nil, err
+		}
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, layout.width, layout.height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+	for _, c := range layout.cells {
+		fill := image.NewUniform(levels[c.level])
+		draw.Draw(img, image.Rect(c.x, c.y, c.x+gridCellSize, c.y+gridCellSize), fill, image.Point{}, draw.Src)
+	}
+
+	drawer := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(text),
+		Face: basicfont.Face7x13,
+	}
+	for _, l := range layout.labels {
+		drawer.Dot = fixed.P(l.x, l.y)
+		drawer.DrawString(l.text)
+	}
+
+	var out image.Image = img
+	if scale > 1 {
+		out = scaleImageNearest(img, scale)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, out); err != nil {
+		return nil, fmt.Errorf("encode png: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// scaleImageNearest 以最近邻方式整数倍放大图片，保持格子边缘清晰。
+func scaleImageNearest(src *image.RGBA, scale int) *image.RGBA {
+	b := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx()*scale, b.Dy()*scale))
+	for y := 0; y < dst.Bounds().Dy(); y++ {
+		for x := 0; x < dst.Bounds().Dx(); x++ {
+			dst.SetRGBA(x, y, src.RGBAAt(b.Min.X+x/scale, b.Min.Y+y/scale))
+		}
+	}
+	return dst
+}
+
+// parseHexColor 解析 #rrggbb 格式的颜色。
+func parseHexColor(hex string) (color.RGBA, error) {
+	digits := strings.TrimPrefix(hex, "#")
+	value, err := strconv.ParseUint(digits, 16, 32)
+	if len(digits) != 6 || err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid colour %q, expected #rrggbb", hex)
+	}
+	return color.RGBA{R: uint8(value >> 16), G: uint8(value >> 8), B: uint8(value), A: 0xff}, nil
+}
+
+// renderContributionImage 按格式 (svg/png) 渲染贡献图。
+func renderContributionImage(contributions []ContributionDay, format string, opts GridRenderOptions) ([]byte, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", "svg":
+		return renderContributionSVG(contributions, opts)
+	case "png":
+		return renderContributionPNG(contributions, opts)
+	default:
+		return nil, fmt.Errorf("unsupported image format %q", format)
+	}
+}
+
+// ExportImageRequest 定义导出贡献图图片的请求。
+type ExportImageRequest struct {
+	Contributions []ContributionDay `json:"contributions"` // 贡献数据
+	Format        string            `json:"format"`        // svg 或 png，默认 svg
+	Options       GridRenderOptions `json:"options"`       // 渲染选项
+}
+
+// ExportContributionImage 将贡献图渲染为 SVG 或 PNG 图片并通过保存对话框写入磁盘。
+func (a *App) ExportContributionImage(req ExportImageRequest) (*ExportContributionsResponse, error) {
+	format := strings.ToLower(strings.TrimSpace(req.Format))
+	if format == "" {
+		format = "svg"
+	}
+	LogInfo("开始导出贡献图图片",
+		zap.String("format", format),
+		zap.String("theme", req.Options.Theme),
+		zap.Int("count", len(req.Contributions)))
+
+	data, err := renderContributionImage(req.Contributions, format, req.Options)
+	if err != nil {
+		LogError("渲染贡献图失败", zap.Error(err))
+		return nil, fmt.Errorf("render contribution image: %w", err)
+	}
+
+	filePath, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "导出贡献图图片",
+		DefaultFilename: "contributions." + format,
+		Filters: []runtime.FileFilter{
+			{DisplayName: fmt.Sprintf("%s Files (*.%s)", strings.ToUpper(format), format), Pattern: "*." + format},
+		},
+	})
+	if err != nil {
+		LogError("保存文件对话框失败", zap.Error(err))
+		return nil, fmt.Errorf("save file dialog: %w", err)
+	}
+	if filePath == "" {
+		LogInfo("用户取消了导出操作")
+		return &ExportContributionsResponse{Success: false, Message: "用户取消了导出操作"}, nil
+	}
+
+	if err := os.WriteFile(filePath, data, 0o644); err != nil {
+		LogError("写入文件失败", zap.String("path", filePath), zap.Error(err))
+		return nil, fmt.Errorf("write file: %w", err)
+	}
+
+	LogInfo("导出贡献图图片成功", zap.String("path", filePath), zap.Int("size", len(data)))
+	return &ExportContributionsResponse{Success: true, Message: "导出成功", FilePath: filePath}, nil
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// gridFixture 覆盖全部画笔色阶，两种着色方式得到的色阶不同，并包含一个年份之外的日期。
+var gridFixture = []ContributionDay{
+	{Date: "2024-01-01", Count: 1},
+	{Date: "2024-02-01", Count: 2},
+	{Date: "2024-02-02", Count: 2},
+	{Date: "2024-02-03", Count: 2},
+	{Date: "2024-01-02", Count: 5},
+	{Date: "2024-01-03", Count: 10},
+	{Date: "2024-01-04", Count: 20},
+	{Date: "2024-03-15", Count: 3},
+	{Date: "2024-06-30", Count: 12},
+	{Date: "2024-12-31", Count: 40},
+	{Date: "2025-01-01", Count: 7},
+}
+
+// checkGolden 比较输出与 testdata 中的金样文件，使用 -update 重新生成。
+func checkGolden(t *testing.T, name string, got []byte, equal func(want, got []byte) bool) {
+	t.Helper()
+	path := filepath.Join("testdata", "grid", name)
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file (run go test -update to create it): %v", err)
+	}
+	if !equal(want, got) {
+		t.Errorf("%s does not match the golden file; run go test -update and review the diff", name)
+	}
+}
+
+// samePixels 比较两张 PNG 的像素，不依赖压缩器的具体输出。
+func samePixels(t *testing.T) func(want, got []byte) bool {
+	return func(want, got []byte) bool {
+		wantImg, err := png.Decode(bytes.NewReader(want))
+		if err != nil {
+			t.Fatal(err)
+		}
+		gotImg, err := png.Decode(bytes.NewReader(got))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if wantImg.Bounds() != gotImg.Bounds() {
+			return false
+		}
+		b := wantImg.Bounds()
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				if color.RGBAModel.Convert(wantImg.At(x, y)) != color.RGBAModel.Convert(gotImg.At(x, y)) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+}
+
+func TestRenderContributionImageGolden(t *testing.T) {
+	for _, theme := range []string{gridThemeLight, gridThemeDark} {
+		for _, shading := range []string{gridShadingDrawing, gridShadingGitHub} {
+			opts := GridRenderOptions{Year: 2024, Theme: theme, Shading: shading}
+			name := theme + "-" + shading
+
+			svg, err := renderContributionImage(gridFixture, "svg", opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, name+".svg", svg, bytes.Equal)
+
+			data, err := renderContributionImage(gridFixture, "png", opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, name+".png", data, samePixels(t))
+		}
+	}
+}
+
+func TestRenderContributionPNGScale(t *testing.T) {
+	small, err := renderContributionPNG(gridFixture, GridRenderOptions{Year: 2024})
+	if err != nil {
+		t.Fatal(err)
+	}
+	large, err := renderContributionPNG(gridFixture, GridRenderOptions{Year: 2024, Scale: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	smallCfg, _, err := image.DecodeConfig(bytes.NewReader(small))
+	if err != nil {
+		t.Fatal(err)
+	}
+	largeCfg, _, err := image.DecodeConfig(bytes.NewReader(large))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if largeCfg.Width != smallCfg.Width*3 || largeCfg.Height != smallCfg.Height*3 {
+		t.Errorf("scaled size %dx%d, want 3x %dx%d", largeCfg.Width, largeCfg.Height, smallCfg.Width, smallCfg.Height)
+	}
+	if _, err := renderContributionPNG(gridFixture, GridRenderOptions{Year: 2024, Scale: gridMaxPNGScale + 1}); err == nil {
+		t.Error("expected error for an oversized scale")
+	}
+}
+
+func TestRenderContributionImageErrors(t *testing.T) {
+	if _, err := renderContributionImage(gridFixture, "gif", GridRenderOptions{Year: 2024}); err == nil {
+		t.Error("expected error for an unsupported format")
+	}
+	if _, err := renderContributionImage(gridFixture, "svg", GridRenderOptions{Year: 2024, Theme: "sepia"}); err == nil {
+		t.Error("expected error for an unknown theme")
+	}
+}
+
+func TestRenderContributionPNGRejectsBadPalette(t *testing.T) {
+	original := gridPalettes[gridThemeLight]
+	t.Cleanup(func() { gridPalettes[gridThemeLight] = original })
+	broken := original
+	broken.levels[2] = "#40c46"
+	gridPalettes[gridThemeLight] = broken
+
+	if _, err := renderContributionPNG(gridFixture, GridRenderOptions{Year: 2024}); err == nil {
+		t.Error("expected error for a malformed palette colour")
+	}
+}
+
+func TestParseHexColor(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    color.RGBA
+		wantErr bool
+	}{
+		{in: "#216e39", want: color.RGBA{R: 0x21, G: 0x6e, B: 0x39, A: 0xff}},
+		{in: "FFFFFF", want: color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}},
+		{in: "#000000", want: color.RGBA{A: 0xff}},
+		{in: "#fff", wantErr: true},
+		{in: "#12345g", wantErr: true},
+		{in: "#+12345", wantErr: true},
+		{in: "#1234567", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseHexColor(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseHexColor(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseHexColor(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
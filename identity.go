@@ -0,0 +1,89 @@
+// identity.go 解析生成提交时使用的作者身份，避免在用户未填写时使用他人的身份。
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// 身份信息的来源。
+const (
+	identitySourceRequest   = "request"    // 请求中显式填写
+	identitySourceGitHub    = "github"     // 当前登录的 GitHub 账号
+	identitySourceGitConfig = "git-config" // 全局 git config user.name / user.email
+	identitySourceNoreply   = "noreply"    // 由 GitHub 用户名推导的 noreply 邮箱
+)
+
+// CommitIdentity 是生成提交时使用的作者身份。
+type CommitIdentity struct {
+	Name        string `json:"name"`        // 作者名称
+	Email       string `json:"email"`       // 作者邮箱
+	NameSource  string `json:"nameSource"`  // 名称来源：request/github/git-config
+	EmailSource string `json:"emailSource"` // 邮箱来源：request/github/git-config/noreply
+}
+
+// resolveCommitIdentity 依次使用请求中的值、登录的 GitHub 账号、全局 git 配置补全作者身份，
+// 邮箱仍为空时使用 <login>@users.noreply.github.com，无法确定时返回错误。
+// 只读取保存的登录信息与 git 配置，不写入任何文件，可在预览 (PlanGeneration) 中使用。
+func (a *App) resolveCommitIdentity(name, email string) (*CommitIdentity, error) {
+	id := &CommitIdentity{Name: strings.TrimSpace(name), Email: strings.TrimSpace(email)}
+	if id.Name != "" {
+		id.NameSource = identitySourceRequest
+	}
+	if id.Email != "" {
+		id.EmailSource = identitySourceRequest
+	}
+	if id.Name != "" && id.Email != "" {
+		return id, nil
+	}
+
+	userInfo := a.userInfo
+	if userInfo == nil {
+		// 命令行运行或预览时尚未加载登录信息：只读取保存的文件，不创建配置目录，也不缓存到 a.userInfo
+		var err error
+		if userInfo, err = readUserInfo(userInfoFilePath()); err != nil {
+			LogWarn("加载用户信息失败", zap.Error(err))
+		}
+	}
+	if userInfo != nil {
+		if id.Name == "" && userInfo.Username != "" {
+			id.Name, id.NameSource = userInfo.Username, identitySourceGitHub
+		}
+		if id.Email == "" && userInfo.Email != "" {
+			id.Email, id.EmailSource = userInfo.Email, identitySourceGitHub
+		}
+	}
+
+	if id.Name == "" {
+		if value, err := a.runGitOutput("", "config", "--global", "user.name"); err == nil && value != "" {
+			id.Name, id.NameSource = value, identitySourceGitConfig
+		}
+	}
+	if id.Email == "" {
+		if value, err := a.runGitOutput("", "config", "--global", "user.email"); err == nil && value != "" {
+			id.Email, id.EmailSource = value, identitySourceGitConfig
+		}
+	}
+
+	if id.Email == "" {
+		login := strings.TrimSpace(name)
+		if login == "" && userInfo != nil {
+			login = userInfo.Username
+		}
+		if login != "" && !strings.ContainsAny(login, " \t") {
+			id.Email, id.EmailSource = login+"@users.noreply.github.com", identitySourceNoreply
+		}
+	}
+
+	if id.Name == "" || id.Email == "" {
+		return nil, fmt.Errorf("commit author is unknown: fill in the GitHub username and email, log in to GitHub, or set git config --global user.name/user.email")
+	}
+	LogInfo("解析提交作者身份",
+		zap.String("name", id.Name),
+		zap.String("name_source", id.NameSource),
+		zap.String("email", id.Email),
+		zap.String("email_source", id.EmailSource))
+	return id, nil
+}
@@ -0,0 +1,244 @@
+// image_convert.go 将图片缩放到某一年的贡献图网格（7 行 × 周数列），并按亮度量化为 0-4 色阶。
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"go.uber.org/zap"
+)
+
+// 支持的抖动算法。
+const (
+	ditherThreshold      = "threshold"       // 直接取最接近的色阶
+	ditherOrdered        = "ordered"         // 4x4 Bayer 有序抖动
+	ditherFloydSteinberg = "floyd-steinberg" // Floyd–Steinberg 误差扩散
+)
+
+// bayer4x4 是 4x4 的 Bayer 有序抖动矩阵。
+var bayer4x4 = [4][4]float64{
+	{0, 8, 2, 10},
+	{12, 4, 14, 6},
+	{3, 11, 1, 9},
+	{15, 7, 13, 5},
+}
+
+// ImageToContributionsRequest 定义图片转换请求。
+type ImageToContributionsRequest struct {
+	ImageData string `json:"imageData"` // Base64 编码的图片或 data URL，为空时弹出文件选择对话框
+	Year      int    `json:"year"`      // 目标年份
+	Dither    string `json:"dither"`    // 抖动算法：threshold/ordered/floyd-steinberg，默认 threshold
+	Invert    bool   `json:"invert"`    // 默认越暗的像素色阶越高；为 true 时越亮的像素色阶越高
+}
+
+// ImageToContributionsResponse 返回转换结果。
+type ImageToContributionsResponse struct {
+	Contributions []ContributionDay `json:"contributions"` // 可直接用于 GenerateRepo 的提交数
+	Columns       int               `json:"columns"`       // 网格列数（周数）
+	TotalCommits  int               `json:"totalCommits"`  // 提交总数
+}
+
+// ConvertImageToContributions 将 PNG/JPEG/GIF 图片转换为指定年份的贡献数据。
+func (a *App) ConvertImageToContributions(req ImageToContributionsRequest) (*ImageToContributionsResponse, error) {
+	LogInfo("开始转换图片为贡献图", zap.Int("year", req.Year), zap.String("dither", req.Dither))
+
+	img, format, err := a.readImage(req.ImageData)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := imageToContributions(img, req.Year, req.Dither, req.Invert)
+	if err != nil {
+		LogError("图片转换失败", zap.Error(err))
+		return nil, err
+	}
+
+	LogInfo("图片转换完成",
+		zap.String("format", format),
+		zap.Int("columns", resp.Columns),
+		zap.Int("days", len(resp.Contributions)),
+		zap.Int("total_commits", resp.TotalCommits))
+	return resp, nil
+}
+
+// readImage 解码 Base64 或 data URL 形式的图片，imageData 为空时弹出文件选择对话框。
+func (a *App) readImage(imageData string) (image.Image, string, error) {
+	var data []byte
+	if strings.TrimSpace(imageData) == "" {
+		filePath, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+			Title: "选择图片",
+			Filters: []runtime.FileFilter{
+				{DisplayName: "图片 (*.png, *.jpg, *.jpeg, *.gif)", Pattern: "*.png;*.jpg;*.jpeg;*.gif"},
+			},
+		})
+		if err != nil {
+			LogError("打开文件对话框失败", zap.Error(err))
+			return nil, "", fmt.Errorf("open file dialog: %w", err)
+		}
+		if filePath == "" {
+			LogInfo("用户取消了图片选择")
+			return nil, "", fmt.Errorf("import cancelled")
+		}
+		if data, err = os.ReadFile(filePath); err != nil {
+			LogError("读取图片失败", zap.String("path", filePath), zap.Error(err))
+			return nil, "", fmt.Errorf("read image: %w", err)
+		}
+	} else {
+		encoded := imageData
+		if i := strings.Index(encoded, ","); strings.HasPrefix(encoded, "data:") && i >= 0 {
+			encoded = encoded[i+1:]
+		}
+		var err error
+		if data, err = base64.StdEncoding.DecodeString(encoded); err != nil {
+			return nil, "", fmt.Errorf("decode image data: %w", err)
+		}
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		LogError("解码图片失败", zap.Error(err))
+		return nil, "", fmt.Errorf("decode image: %w", err)
+	}
+	return img, format, nil
+}
+
+// imageToContributions 将图片缩放到 year 年的网格并转换为贡献数据。
+func imageToContributions(img image.Image, year int, dither string, invert bool) (*ImageToContributionsResponse, error) {
+	if year <= 0 {
+		return nil, fmt.Errorf("invalid year: %d", year)
+	}
+	offset, cols := yearGridShape(year)
+	levels, err := imageLevelGrid(img, cols, dither, invert)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ImageToContributionsResponse{Columns: cols}
+	for i, date := range datesOfYear(year) {
+		x, y := (i+offset)/7, (i+offset)%7
+		if count := drawingCountForLevel(levels[y][x]); count > 0 {
+			resp.Contributions = append(resp.Contributions, ContributionDay{Date: date, Count: count})
+			resp.TotalCommits += count
+		}
+	}
+	return resp, nil
+}
+
+// imageLevelGrid 将图片按区域平均亮度缩放为 7 行 × cols 列的网格，再用指定算法量化为色阶。
+func imageLevelGrid(img image.Image, cols int, dither string, invert bool) ([][]int, error) {
+	bounds := img.Bounds()
+	if bounds.Empty() {
+		return nil, fmt.Errorf("image is empty")
+	}
+	const rows = 7
+
+	// 每个格子的目标色阶（连续值，0-4）
+	values := make([][]float64, rows)
+	for y := 0; y < rows; y++ {
+		values[y] = make([]float64, cols)
+		for x := 0; x < cols; x++ {
+			darkness := 1 - averageLuminance(img, cellRect(bounds, x, y, cols, rows))
+			if invert {
+				darkness = 1 - darkness
+			}
+			values[y][x] = darkness * maxContributionLevel
+		}
+	}
+	return quantiseLevels(values, dither)
+}
+
+// cellRect 计算网格中第 (x, y) 个格子在原图上对应的像素区域，保证至少包含一个像素。
+func cellRect(bounds image.Rectangle, x, y, cols, rows int) image.Rectangle {
+	w, h := bounds.Dx(), bounds.Dy()
+	x0 := bounds.Min.X + x*w/cols
+	x1 := bounds.Min.X + (x+1)*w/cols
+	y0 := bounds.Min.Y + y*h/rows
+	y1 := bounds.Min.Y + (y+1)*h/rows
+	if x1 <= x0 {
+		x1 = x0 + 1
+	}
+	if y1 <= y0 {
+		y1 = y0 + 1
+	}
+	return image.Rect(x0, y0, x1, y1).Intersect(bounds)
+}
+
+// averageLuminance 计算区域内的平均亮度 (0-1)，透明像素视为叠加在白色背景上。
+func averageLuminance(img image.Image, rect image.Rectangle) float64 {
+	var sum float64
+	var n int
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			r, g, b, a := img.At(x, y).RGBA()
+			// RGBA() 返回预乘 alpha 的分量，叠加白色背景只需补上 (1 - alpha)
+			bg := float64(0xffff - a)
+			lum := 0.2126*(float64(r)+bg) + 0.7152*(float64(g)+bg) + 0.0722*(float64(b)+bg)
+			sum += lum / 0xffff
+			n++
+		}
+	}
+	if n == 0 {
+		return 1
+	}
+	return sum / float64(n)
+}
+
+// quantiseLevels 使用指定的抖动算法将连续色阶量化为 0-4 的整数。
+func quantiseLevels(values [][]float64, dither string) ([][]int, error) {
+	rows := len(values)
+	levels := make([][]int, rows)
+	for y := range levels {
+		levels[y] = make([]int, len(values[y]))
+	}
+	clamp := func(v float64) int {
+		return int(math.Max(0, math.Min(maxContributionLevel, v)))
+	}
+
+	switch strings.ToLower(strings.TrimSpace(dither)) {
+	case "", ditherThreshold:
+		for y := range values {
+			for x, v := range values[y] {
+				levels[y][x] = clamp(math.Round(v))
+			}
+		}
+	case ditherOrdered:
+		for y := range values {
+			for x, v := range values[y] {
+				levels[y][x] = clamp(math.Floor(v + (bayer4x4[y%4][x%4]+0.5)/16))
+			}
+		}
+	case ditherFloydSteinberg:
+		work := make([][]float64, rows)
+		for y := range values {
+			work[y] = append([]float64(nil), values[y]...)
+		}
+		spread := func(y, x int, amount float64) {
+			if y >= 0 && y < rows && x >= 0 && x < len(work[y]) {
+				work[y][x] += amount
+			}
+		}
+		for y := range work {
+			for x := range work[y] {
+				level := clamp(math.Round(work[y][x]))
+				levels[y][x] = level
+				e := work[y][x] - float64(level)
+				spread(y, x+1, e*7/16)
+				spread(y+1, x-1, e*3/16)
+				spread(y+1, x, e*5/16)
+				spread(y+1, x+1, e*1/16)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported dither %q", dither)
+	}
+	return levels, nil
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+// columnImage 创建一张 7 像素高的灰度图，每一列使用 grays 中对应的灰度。
+func columnImage(grays ...uint8) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, len(grays), 7))
+	for x, g := range grays {
+		for y := 0; y < 7; y++ {
+			img.SetGray(x, y, color.Gray{Y: g})
+		}
+	}
+	return img
+}
+
+func TestImageLevelGridThreshold(t *testing.T) {
+	img := columnImage(255, 191, 128, 0)
+	tests := []struct {
+		invert bool
+		want   []int
+	}{
+		{invert: false, want: []int{0, 1, 2, 4}},
+		{invert: true, want: []int{4, 3, 2, 0}},
+	}
+	for _, tt := range tests {
+		levels, err := imageLevelGrid(img, 4, ditherThreshold, tt.invert)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for y, row := range levels {
+			if !reflect.DeepEqual(row, tt.want) {
+				t.Errorf("invert %v: row %d = %v, want %v", tt.invert, y, row, tt.want)
+			}
+		}
+	}
+
+	// 透明像素叠加在白色背景上，视为空白
+	transparent := image.NewNRGBA(image.Rect(0, 0, 1, 7))
+	levels, err := imageLevelGrid(transparent, 1, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for y, row := range levels {
+		if row[0] != 0 {
+			t.Errorf("transparent row %d = %v, want 0", y, row)
+		}
+	}
+
+	if _, err := imageLevelGrid(image.NewGray(image.Rect(0, 0, 0, 0)), 4, "", false); err == nil {
+		t.Error("empty image: expected error")
+	}
+}
+
+func TestQuantiseLevels(t *testing.T) {
+	uniform := func(rows, cols int, v float64) [][]float64 {
+		values := make([][]float64, rows)
+		for y := range values {
+			values[y] = make([]float64, cols)
+			for x := range values[y] {
+				values[y][x] = v
+			}
+		}
+		return values
+	}
+	tests := []struct {
+		name   string
+		values [][]float64
+		dither string
+		want   [][]int
+	}{
+		{
+			name:   "threshold rounds and clamps",
+			values: [][]float64{{-1, 0.49, 1.5, 3.6, 9}},
+			dither: ditherThreshold,
+			want:   [][]int{{0, 0, 2, 4, 4}},
+		},
+		{
+			// 介于两个色阶之间的均匀灰度按 Bayer 矩阵交错取两个色阶
+			name:   "ordered checkerboard",
+			values: uniform(4, 4, 2.5),
+			dither: ditherOrdered,
+			want:   [][]int{{2, 3, 2, 3}, {3, 2, 3, 2}, {2, 3, 2, 3}, {3, 2, 3, 2}},
+		},
+		{
+			name:   "ordered keeps whole levels",
+			values: uniform(2, 4, 1),
+			dither: ditherOrdered,
+			want:   [][]int{{1, 1, 1, 1}, {1, 1, 1, 1}},
+		},
+		{
+			// 误差向右扩散，半色阶的一行交替取整
+			name:   "floyd-steinberg spreads the error",
+			values: uniform(1, 4, 0.5),
+			dither: ditherFloydSteinberg,
+			want:   [][]int{{1, 0, 1, 0}},
+		},
+		{
+			name:   "floyd-steinberg keeps extremes",
+			values: [][]float64{{0, 4}, {4, 0}},
+			dither: " Floyd-Steinberg ",
+			want:   [][]int{{0, 4}, {4, 0}},
+		},
+	}
+	for _, tt := range tests {
+		got, err := quantiseLevels(tt.values, tt.dither)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := quantiseLevels(uniform(1, 1, 1), "atkinson"); err == nil {
+		t.Error("unsupported dither: expected error")
+	}
+}
+
+// 纯黑图片的每一天都是最深色。
+func TestImageToContributionsFillsYear(t *testing.T) {
+	resp, err := imageToContributions(columnImage(0), 2024, ditherThreshold, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	days := len(datesOfYear(2024))
+	if len(resp.Contributions) != days || resp.TotalCommits != days*drawingCountForLevel(maxContributionLevel) {
+		t.Fatalf("got %d days and %d commits, want %d days at level %d", len(resp.Contributions), resp.TotalCommits, days, maxContributionLevel)
+	}
+	if _, cols := yearGridShape(2024); resp.Columns != cols {
+		t.Errorf("columns = %d, want %d", resp.Columns, cols)
+	}
+}
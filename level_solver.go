@@ -0,0 +1,90 @@
+// level_solver.go 将前端的色阶图案 (0-4) 转换为能让 GitHub 按相同色阶着色的最小提交数。
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DayLevel 代表单日的目标色阶。
+type DayLevel struct {
+	Date  string `json:"date"`  // 格式: YYYY-MM-DD
+	Level int    `json:"level"` // 色阶 0-4，0 表示空白
+}
+
+// SolveLevelCountsRequest 定义色阶求解请求。
+type SolveLevelCountsRequest struct {
+	Year   int        `json:"year"`   // 贡献图所展示的年份，为 0 时根据日期推断
+	Levels []DayLevel `json:"levels"` // 每一天的目标色阶，未列出的日期视为空白
+}
+
+// SolveLevelCountsResponse 返回求解结果。
+type SolveLevelCountsResponse struct {
+	Contributions []ContributionDay `json:"contributions"` // 可直接用于 GenerateRepo 的提交数
+	TotalCommits  int               `json:"totalCommits"`  // 提交总数
+	Warnings      []ShadingWarning  `json:"warnings"`      // 色阶分布无法被 GitHub 还原的格子
+}
+
+// SolveLevelCounts 将色阶图案转换为最小提交数，使 GitHub 按分位数规则着色后与目标色阶一致。
+func (a *App) SolveLevelCounts(req SolveLevelCountsRequest) (*SolveLevelCountsResponse, error) {
+	LogInfo("开始求解色阶提交数", zap.Int("year", req.Year), zap.Int("days", len(req.Levels)))
+
+	resp, err := solveLevelCounts(req.Year, req.Levels)
+	if err != nil {
+		LogError("色阶求解失败", zap.Error(err))
+		return nil, err
+	}
+
+	LogInfo("色阶求解完成",
+		zap.Int("total_commits", resp.TotalCommits),
+		zap.Int("warnings", len(resp.Warnings)))
+	return resp, nil
+}
+
+// solveLevelCounts 校验色阶图案并求解每一天所需的最小提交数。
+func solveLevelCounts(year int, dayLevels []DayLevel) (*SolveLevelCountsResponse, error) {
+	if year <= 0 {
+		days := make([]ContributionDay, len(dayLevels))
+		for i, d := range dayLevels {
+			days[i] = ContributionDay{Date: d.Date}
+		}
+		year = inferContributionYear(days)
+	}
+	if year <= 0 {
+		return nil, fmt.Errorf("cannot determine target year")
+	}
+
+	target := make(map[string]int, len(dayLevels))
+	for _, d := range dayLevels {
+		t, err := time.Parse("2006-01-02", d.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q: %w", d.Date, err)
+		}
+		if t.Year() != year {
+			return nil, fmt.Errorf("date %s is outside year %d", d.Date, year)
+		}
+		if d.Level < 0 || d.Level > maxContributionLevel {
+			return nil, fmt.Errorf("invalid level for %s: %d", d.Date, d.Level)
+		}
+		if _, exists := target[d.Date]; exists {
+			return nil, fmt.Errorf("duplicate date %s", d.Date)
+		}
+		target[d.Date] = d.Level
+	}
+
+	dates := datesOfYear(year)
+	final := solveShadingCounts(dates, target, nil)
+
+	resp := &SolveLevelCountsResponse{
+		Warnings: shadingWarnings(dates, target, nil, final),
+	}
+	for _, date := range dates {
+		if final[date] > 0 {
+			resp.Contributions = append(resp.Contributions, ContributionDay{Date: date, Count: final[date]})
+			resp.TotalCommits += final[date]
+		}
+	}
+	return resp, nil
+}
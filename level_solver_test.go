@@ -0,0 +1,213 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// yearCounts 将求解结果展开为整年的贡献数，未列出的日期为 0。
+func yearCounts(year int, contributions []ContributionDay) map[string]int {
+	counts := make(map[string]int)
+	for _, date := range datesOfYear(year) {
+		counts[date] = 0
+	}
+	for _, c := range contributions {
+		counts[c.Date] = c.Count
+	}
+	return counts
+}
+
+func TestSolveLevelCounts(t *testing.T) {
+	tests := []struct {
+		name         string
+		levels       []DayLevel
+		wantCounts   map[string]int
+		wantWarnings int
+	}{
+		{
+			name:       "single darkest cell",
+			levels:     []DayLevel{{Date: "2024-03-01", Level: 4}},
+			wantCounts: map[string]int{"2024-03-01": 1},
+		},
+		{
+			name: "one cell per level",
+			levels: []DayLevel{
+				{Date: "2024-01-01", Level: 1},
+				{Date: "2024-01-02", Level: 2},
+				{Date: "2024-01-03", Level: 3},
+				{Date: "2024-01-04", Level: 4},
+			},
+			wantCounts: map[string]int{"2024-01-01": 1, "2024-01-02": 2, "2024-01-03": 3, "2024-01-04": 4},
+		},
+		{
+			name: "two cells per level",
+			levels: []DayLevel{
+				{Date: "2024-05-01", Level: 1}, {Date: "2024-05-02", Level: 1},
+				{Date: "2024-05-03", Level: 2}, {Date: "2024-05-04", Level: 2},
+				{Date: "2024-05-05", Level: 3}, {Date: "2024-05-06", Level: 3},
+				{Date: "2024-05-07", Level: 4}, {Date: "2024-05-08", Level: 4},
+			},
+			wantCounts: map[string]int{
+				"2024-05-01": 1, "2024-05-02": 1, "2024-05-03": 2, "2024-05-04": 2,
+				"2024-05-05": 3, "2024-05-06": 3, "2024-05-07": 4, "2024-05-08": 4,
+			},
+		},
+		{
+			// 只有浅色格子时，分位数规则会把它们全部画成最深色
+			name:         "only lightest cells",
+			levels:       []DayLevel{{Date: "2024-06-01", Level: 1}, {Date: "2024-06-02", Level: 1}},
+			wantCounts:   map[string]int{"2024-06-01": 1, "2024-06-02": 1},
+			wantWarnings: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := solveLevelCounts(2024, tt.levels)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make(map[string]int)
+			for _, c := range resp.Contributions {
+				got[c.Date] = c.Count
+			}
+			if len(got) != len(tt.wantCounts) {
+				t.Fatalf("counts = %v, want %v", got, tt.wantCounts)
+			}
+			for date, want := range tt.wantCounts {
+				if got[date] != want {
+					t.Errorf("count for %s = %d, want %d", date, got[date], want)
+				}
+			}
+			if len(resp.Warnings) != tt.wantWarnings {
+				t.Errorf("warnings = %v, want %d", resp.Warnings, tt.wantWarnings)
+			}
+
+			target := make(map[string]int)
+			for _, d := range tt.levels {
+				target[d.Date] = d.Level
+			}
+			mismatches := 0
+			for date, level := range githubLevels(yearCounts(2024, resp.Contributions)) {
+				if level != target[date] {
+					mismatches++
+				}
+			}
+			if mismatches != tt.wantWarnings {
+				t.Errorf("githubLevels mismatches = %d, want %d", mismatches, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestSolveLevelCountsErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		year   int
+		levels []DayLevel
+	}{
+		{name: "bad date", year: 2024, levels: []DayLevel{{Date: "2024-13-01", Level: 1}}},
+		{name: "other year", year: 2024, levels: []DayLevel{{Date: "2023-12-31", Level: 1}}},
+		{name: "level too high", year: 2024, levels: []DayLevel{{Date: "2024-01-01", Level: 5}}},
+		{name: "duplicate date", year: 2024, levels: []DayLevel{{Date: "2024-01-01", Level: 1}, {Date: "2024-01-01", Level: 2}}},
+		{name: "no year", levels: nil},
+	}
+	for _, tt := range tests {
+		if _, err := solveLevelCounts(tt.year, tt.levels); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
+
+// 各色阶格子数量相同时分位数规则总能还原目标色阶：随机摆放的图案经 GitHub 着色后必须与目标完全一致。
+func TestSolveLevelCountsBalancedProperty(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	dates := datesOfYear(2023)
+	for iter := 0; iter < 200; iter++ {
+		perLevel := 1 + rng.Intn(len(dates)/maxContributionLevel)
+		order := rng.Perm(len(dates))
+		var levels []DayLevel
+		target := make(map[string]int)
+		for i := 0; i < perLevel*maxContributionLevel; i++ {
+			date := dates[order[i]]
+			level := 1 + i/perLevel
+			levels = append(levels, DayLevel{Date: date, Level: level})
+			target[date] = level
+		}
+
+		resp, err := solveLevelCounts(2023, levels)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Warnings) != 0 {
+			t.Fatalf("iteration %d (%d cells per level): unexpected warnings %v", iter, perLevel, resp.Warnings[0])
+		}
+		for date, level := range githubLevels(yearCounts(2023, resp.Contributions)) {
+			if level != target[date] {
+				t.Fatalf("iteration %d: %s shaded %d, want %d", iter, date, level, target[date])
+			}
+		}
+	}
+}
+
+// 任意图案：结果只增不减、深色格子严格多于浅色格子，且警告恰好是 githubLevels 与目标不一致的格子。
+func TestSolveShadingCountsProperty(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	dates := datesOfYear(2024)
+	for iter := 0; iter < 200; iter++ {
+		target := make(map[string]int)
+		baseline := make(map[string]int)
+		for _, date := range dates {
+			if rng.Intn(3) == 0 {
+				target[date] = rng.Intn(maxContributionLevel + 1)
+			}
+			if iter%2 == 1 && rng.Intn(10) == 0 {
+				baseline[date] = rng.Intn(30)
+			}
+		}
+
+		final := solveShadingCounts(dates, target, baseline)
+		maxByLevel := make([]int, maxContributionLevel+1)
+		minByLevel := make([]int, maxContributionLevel+1)
+		for _, date := range dates {
+			if final[date] < baseline[date] {
+				t.Fatalf("iteration %d: %s reduced from %d to %d", iter, date, baseline[date], final[date])
+			}
+			level := target[date]
+			if level > 0 && final[date] == 0 {
+				t.Fatalf("iteration %d: %s has level %d but no commits", iter, date, level)
+			}
+			if final[date] > maxByLevel[level] {
+				maxByLevel[level] = final[date]
+			}
+			if minByLevel[level] == 0 || final[date] < minByLevel[level] {
+				minByLevel[level] = final[date]
+			}
+		}
+		// 已有贡献可能高于目标色阶，只在没有已有贡献时检查色阶之间的严格顺序
+		for level := 2; level <= maxContributionLevel && len(baseline) == 0; level++ {
+			for lower := 1; lower < level; lower++ {
+				if minByLevel[level] > 0 && maxByLevel[lower] > 0 && minByLevel[level] <= maxByLevel[lower] {
+					t.Fatalf("iteration %d: level %d min %d not above level %d max %d", iter, level, minByLevel[level], lower, maxByLevel[lower])
+				}
+			}
+		}
+
+		levels := githubLevels(final)
+		mismatched := make(map[string]bool)
+		for _, date := range dates {
+			if levels[date] != target[date] {
+				mismatched[date] = true
+			}
+		}
+		warnings := shadingWarnings(dates, target, baseline, final)
+		if len(warnings) != len(mismatched) {
+			t.Fatalf("iteration %d: %d warnings, %d mismatched cells", iter, len(warnings), len(mismatched))
+		}
+		for _, w := range warnings {
+			if !mismatched[w.Date] || w.ActualLevel != levels[w.Date] {
+				t.Fatalf("iteration %d: unexpected warning %+v", iter, w)
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@ package main
 
 import (
 	"embed"
+	"os"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
@@ -15,8 +16,14 @@ import (
 var assets embed.FS
 
 // main 是应用程序的主进入点。
-// 它负责初始化日志系统、创建应用实例，并启动 Wails 框架渲染前端界面。
+// 它负责初始化日志系统、创建应用实例，并启动 Wails 框架渲染前端界面；
+// 如果传入了已知的子命令（见 cli.go），则以命令行模式运行。
 func main() {
+	// 命令行子命令无需启动图形界面
+	if handled, code := runCLI(os.Args[1:]); handled {
+		os.Exit(code)
+	}
+
 	// 初始化日志系统
 	if err := InitLogger(); err != nil {
 		println("Failed to initialize logger:", err.Error())
@@ -0,0 +1,191 @@
+// marquee.go 实现跨年份的“滚动字幕”规划：将过长的文本或过宽的图片切分为连续的若干年，
+// 访客在个人主页上逐年翻看即可读完整段内容。
+package main
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strings"
+
+	"go.uber.org/zap"
+	"green-wall/banner"
+)
+
+// MarqueeRequest 定义滚动字幕规划请求。Text 非空时按文本排版，否则将图片按宽度切分。
+type MarqueeRequest struct {
+	StartYear int    `json:"startYear"` // 第一帧所在的年份
+	MaxYears  int    `json:"maxYears"`  // 最多使用的年份数，0 表示不限制
+	Theme     string `json:"theme"`     // 预览图主题：light 或 dark
+
+	// 文本模式
+	Text    string `json:"text"`    // 要滚动显示的文本
+	Font    string `json:"font"`    // 字体名称，默认 5x7
+	Spacing int    `json:"spacing"` // 在默认 1 列字间距基础上增加的列数
+	Kerning bool   `json:"kerning"` // 按字形实际宽度排版
+	Align   string `json:"align"`   // 每一帧内的对齐方式，默认 center
+	Level   int    `json:"level"`   // 文字色阶 (1-4)，默认 4
+
+	// 图片模式
+	ImageData    string `json:"imageData"`    // Base64 编码的图片或 data URL，为空时弹出文件选择对话框
+	ImageColumns int    `json:"imageColumns"` // 图片缩放后的总列数，0 表示按原图宽高比计算
+	Dither       string `json:"dither"`       // 抖动算法：threshold/ordered/floyd-steinberg
+	Invert       bool   `json:"invert"`       // 越亮的像素色阶越高
+}
+
+// MarqueeFrame 是滚动字幕中的一帧，对应一个年份。
+type MarqueeFrame struct {
+	Year          int               `json:"year"`           // 年份
+	Text          string            `json:"text,omitempty"` // 文本模式下该年显示的片段
+	Contributions []ContributionDay `json:"contributions"`  // 该年的贡献数据
+	TotalCommits  int               `json:"totalCommits"`   // 该年的提交数
+	Preview       string            `json:"preview"`        // 该年贡献图的 SVG 预览
+}
+
+// MarqueeResponse 返回规划结果。
+// Years 可直接传给 GenerateMultiYearRepo，SplitByYear 决定生成一个仓库还是每年一个仓库。
+type MarqueeResponse struct {
+	Frames       []MarqueeFrame      `json:"frames"`       // 按年份排列的预览序列
+	Years        []YearContributions `json:"years"`        // 按年份分组的贡献数据
+	TotalCommits int                 `json:"totalCommits"` // 提交总数
+}
+
+// PlanMarquee 将长文本或宽图片切分为连续年份的贡献计划，并为每一年生成预览图。
+func (a *App) PlanMarquee(req MarqueeRequest) (*MarqueeResponse, error) {
+	LogInfo("开始规划滚动字幕",
+		zap.Int("start_year", req.StartYear),
+		zap.Bool("text_mode", strings.TrimSpace(req.Text) != ""),
+		zap.Int("max_years", req.MaxYears))
+
+	var years []YearContributions
+	var texts []string
+	var err error
+	if strings.TrimSpace(req.Text) != "" {
+		years, texts, err = planTextMarquee(req)
+	} else {
+		var img image.Image
+		if img, _, err = a.readImage(req.ImageData); err != nil {
+			return nil, err
+		}
+		years, err = planImageMarquee(img, req)
+	}
+	if err != nil {
+		LogError("滚动字幕规划失败", zap.Error(err))
+		return nil, err
+	}
+
+	resp, err := buildMarqueeResponse(years, texts, req.Theme)
+	if err != nil {
+		LogError("生成滚动字幕预览失败", zap.Error(err))
+		return nil, err
+	}
+	LogInfo("滚动字幕规划完成", zap.Int("years", len(resp.Frames)), zap.Int("total_commits", resp.TotalCommits))
+	return resp, nil
+}
+
+// planTextMarquee 使用 banner 包的滚动排版，每一年为一帧。
+func planTextMarquee(req MarqueeRequest) ([]YearContributions, []string, error) {
+	result, err := renderTextBanner(TextBannerRequest{
+		Text:     req.Text,
+		Year:     req.StartYear,
+		Font:     req.Font,
+		Spacing:  req.Spacing,
+		Kerning:  req.Kerning,
+		Align:    req.Align,
+		Level:    req.Level,
+		Scroll:   true,
+		MaxYears: req.MaxYears,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	texts := make([]string, len(result.Pages))
+	for i, page := range result.Pages {
+		texts[i] = page.Text
+	}
+	return result.Years, texts, nil
+}
+
+// planImageMarquee 将图片缩放为 7 行的长条并整体抖动，再按每年完整的周数依次切分。
+func planImageMarquee(img image.Image, req MarqueeRequest) ([]YearContributions, error) {
+	if req.StartYear <= 0 {
+		return nil, fmt.Errorf("invalid year: %d", req.StartYear)
+	}
+	if req.MaxYears < 0 {
+		return nil, fmt.Errorf("invalid max years: %d", req.MaxYears)
+	}
+	bounds := img.Bounds()
+	if bounds.Empty() {
+		return nil, fmt.Errorf("image is empty")
+	}
+	cols := req.ImageColumns
+	if cols < 0 {
+		return nil, fmt.Errorf("invalid image columns: %d", cols)
+	}
+	if cols == 0 {
+		// 贡献图的格子是正方形，保持原图宽高比
+		cols = max(1, int(math.Round(float64(bounds.Dx())*7/float64(bounds.Dy()))))
+	}
+
+	// 先统计需要的年份数，避免对超限的请求做无用的计算
+	needed := 0
+	for col := 0; col < cols; needed++ {
+		_, width := banner.UsableColumns(req.StartYear + needed)
+		col += width
+	}
+	if req.MaxYears > 0 && needed > req.MaxYears {
+		return nil, fmt.Errorf("image needs %d years but at most %d are allowed", needed, req.MaxYears)
+	}
+
+	levels, err := imageLevelGrid(img, cols, req.Dither, req.Invert)
+	if err != nil {
+		return nil, err
+	}
+
+	years := make([]YearContributions, 0, needed)
+	col := 0
+	for i := 0; i < needed; i++ {
+		year := req.StartYear + i
+		first, width := banner.UsableColumns(year)
+		offset, _ := yearGridShape(year)
+		dates := datesOfYear(year)
+		frame := YearContributions{Year: year}
+		for x := 0; x < width && col+x < cols; x++ {
+			for y := 0; y < 7; y++ {
+				count := drawingCountForLevel(levels[y][col+x])
+				if count == 0 {
+					continue
+				}
+				frame.Contributions = append(frame.Contributions, ContributionDay{
+					Date:  dates[(first+x)*7+y-offset],
+					Count: count,
+				})
+			}
+		}
+		col += width
+		years = append(years, frame)
+	}
+	return years, nil
+}
+
+// buildMarqueeResponse 汇总每一帧的提交数并渲染预览图。
+func buildMarqueeResponse(years []YearContributions, texts []string, theme string) (*MarqueeResponse, error) {
+	resp := &MarqueeResponse{Years: years}
+	for i, year := range years {
+		frame := MarqueeFrame{Year: year.Year, Contributions: year.Contributions}
+		if i < len(texts) {
+			frame.Text = texts[i]
+		}
+		for _, c := range year.Contributions {
+			frame.TotalCommits += c.Count
+		}
+		svg, err := renderContributionSVG(year.Contributions, GridRenderOptions{Year: year.Year, Theme: theme})
+		if err != nil {
+			return nil, err
+		}
+		frame.Preview = string(svg)
+		resp.Frames = append(resp.Frames, frame)
+		resp.TotalCommits += frame.TotalCommits
+	}
+	return resp, nil
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+	"time"
+
+	"green-wall/banner"
+)
+
+// marqueeCell 将某一帧中的日期换算为该年贡献图上的 (列, 行)。
+func marqueeCell(t *testing.T, year int, date string) (col, row int) {
+	t.Helper()
+	d, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Year() != year {
+		t.Fatalf("%s is outside frame year %d", date, year)
+	}
+	offset, _ := yearGridShape(year)
+	i := d.YearDay() - 1 + offset
+	return i / 7, i % 7
+}
+
+// 图片按列依次铺满各年完整的周：把每一帧的格子拼回去必须与原图逐列一致，且不落在首尾不完整的周。
+func TestPlanImageMarqueeColumnContinuity(t *testing.T) {
+	const cols = 120
+	// 第 x 列按 x+1 的二进制点亮各行，保证每一列的图案互不相同
+	img := image.NewGray(image.Rect(0, 0, cols, 7))
+	want := make(map[[2]int]bool)
+	for x := 0; x < cols; x++ {
+		for y := 0; y < 7; y++ {
+			g := uint8(255)
+			if (x+1)>>y&1 == 1 {
+				g = 0
+				want[[2]int{x, y}] = true
+			}
+			img.SetGray(x, y, color.Gray{Y: g})
+		}
+	}
+
+	years, err := planImageMarquee(img, MarqueeRequest{StartYear: 2024, ImageColumns: cols})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(years) != 3 {
+		t.Fatalf("got %d frames, want 3", len(years))
+	}
+	got := make(map[[2]int]bool)
+	start := 0
+	for i, frame := range years {
+		if frame.Year != 2024+i {
+			t.Fatalf("frame %d year = %d, want %d", i, frame.Year, 2024+i)
+		}
+		first, width := banner.UsableColumns(frame.Year)
+		for _, c := range frame.Contributions {
+			col, row := marqueeCell(t, frame.Year, c.Date)
+			if col < first || col >= first+width {
+				t.Errorf("%s lies in column %d outside the full weeks %d-%d of %d", c.Date, col, first, first+width-1, frame.Year)
+			}
+			if c.Count != drawingCountForLevel(maxContributionLevel) {
+				t.Errorf("%s count = %d", c.Date, c.Count)
+			}
+			got[[2]int{start + col - first, row}] = true
+		}
+		start += width
+	}
+	if len(got) != len(want) {
+		t.Fatalf("reassembled %d cells, want %d", len(got), len(want))
+	}
+	for cell := range want {
+		if !got[cell] {
+			t.Errorf("column %d row %d is missing after splitting", cell[0], cell[1])
+		}
+	}
+
+	if _, err := planImageMarquee(img, MarqueeRequest{StartYear: 2024, ImageColumns: cols, MaxYears: 2}); err == nil {
+		t.Error("max years: expected error")
+	}
+}
+
+// 文本按单词顺延到连续的年份，每一帧只包含本年的日期，并附带预览图。
+func TestPlanMarqueeText(t *testing.T) {
+	a := newTestApp(t)
+	const text = "GREEN WALL SCROLLS ACROSS YEARS"
+	resp, err := a.PlanMarquee(MarqueeRequest{StartYear: 2024, Text: text, Align: "left"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Frames) < 2 || len(resp.Frames) != len(resp.Years) {
+		t.Fatalf("got %d frames and %d years, want the text spread over several years", len(resp.Frames), len(resp.Years))
+	}
+
+	var texts []string
+	total := 0
+	for i, frame := range resp.Frames {
+		if frame.Year != 2024+i || resp.Years[i].Year != frame.Year {
+			t.Fatalf("frame %d year = %d, want %d", i, frame.Year, 2024+i)
+		}
+		if frame.Text == "" || strings.HasPrefix(frame.Text, " ") || strings.HasSuffix(frame.Text, " ") {
+			t.Errorf("frame %d text = %q", i, frame.Text)
+		}
+		texts = append(texts, frame.Text)
+
+		first, width := banner.UsableColumns(frame.Year)
+		sum := 0
+		for _, c := range frame.Contributions {
+			if col, _ := marqueeCell(t, frame.Year, c.Date); col < first || col >= first+width {
+				t.Errorf("frame %d: %s lies outside the full weeks", i, c.Date)
+			}
+			sum += c.Count
+		}
+		if sum == 0 || frame.TotalCommits != sum {
+			t.Errorf("frame %d total = %d, contributions sum to %d", i, frame.TotalCommits, sum)
+		}
+		if !strings.HasPrefix(frame.Preview, "<svg") {
+			t.Errorf("frame %d preview is not an SVG", i)
+		}
+		total += sum
+	}
+	if got := strings.Join(texts, " "); got != text {
+		t.Errorf("frames read %q, want %q", got, text)
+	}
+	if resp.TotalCommits != total {
+		t.Errorf("total = %d, want %d", resp.TotalCommits, total)
+	}
+
+	if _, err := a.PlanMarquee(MarqueeRequest{StartYear: 2024, Text: text, MaxYears: 1}); err == nil {
+		t.Error("max years: expected error")
+	}
+}
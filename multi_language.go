@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"go.uber.org/zap"
@@ -65,16 +66,17 @@ type LanguageConfig struct {
 	Ratio    int    `json:"ratio"`    // 预期的所占比例 (0-100)
 }
 
-// generateMultiLanguageReadme 为多语言混合仓库生成 README.md 内容。
-func generateMultiLanguageReadme(repoName string, languageConfigs []LanguageConfig) string {
+// generateMultiLanguageReadme 为多语言混合仓库生成 README.md 内容，Structure 一节按文件布局描述活动文件。
+func generateMultiLanguageReadme(repoName string, languageConfigs []LanguageConfig, layout languages.FileLayout) string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("# %s\n\n", repoName))
 	sb.WriteString("Generated with [GreenWall](https://github.com/Cail-Gainey/GreenWall).\n\n")
 	
 	// 如果只有一种语言，降级使用该语言的专属模板
 	if len(languageConfigs) == 1 {
-		template := languages.GetLanguageTemplate(languages.LanguageType(languageConfigs[0].Language))
-		return template.GetReadmeContent(repoName)
+		lang := languages.LanguageType(languageConfigs[0].Language)
+		template := languages.GetLanguageTemplate(lang)
+		return languages.ApplyStructureLines(template.GetReadmeContent(repoName), []string{languages.LayoutStructureLine(lang, layout)})
 	}
 	
 	// 多语言说明部分
@@ -89,6 +91,11 @@ func generateMultiLanguageReadme(repoName string, languageConfigs []LanguageConf
 	sb.WriteString("\n## About\n\n")
 	sb.WriteString("This is an automatically generated repository showcasing contributions across different programming languages.\n\n")
 	sb.WriteString("Each commit uses a different language based on the configured ratios, creating a diverse and colorful contribution graph.\n\n")
+	sb.WriteString("## Structure\n\n")
+	for _, config := range languageConfigs {
+		sb.WriteString(languages.LayoutStructureLine(languages.LanguageType(config.Language), layout) + "\n")
+	}
+	sb.WriteString("- `README.md` - This file\n\n")
 	sb.WriteString("## License\n\nMIT License\n")
 	
 	return sb.String()
@@ -98,7 +105,8 @@ func generateMultiLanguageReadme(repoName string, languageConfigs []LanguageConf
 // 这里的关键是引入了“代码量补偿机制”：
 // 因为 GitHub 以字节数统计比例，所以产生代码量大的语言（如 Vue）会占据更多的权重，
 // 该算法通过调整分配概率，确保 GitHub 最终统计出来的饼图与用户设置的期望比例一致。
-func selectLanguageByRatio(languageConfigs []LanguageConfig, commitIndex int) string {
+// 增量内容模式下每个提交只新增一段片段，按片段的字节数计算权重。
+func selectLanguageByRatio(languageConfigs []LanguageConfig, commitIndex int, mode languages.ContentMode) string {
 	if len(languageConfigs) == 0 {
 		return "markdown"
 	}
@@ -123,6 +131,9 @@ func selectLanguageByRatio(languageConfigs []LanguageConfig, commitIndex int) st
 		// 补偿公式：权重 = 目标比例 * 10000 / 每提交平均字节数
 		// 字节数越大的语言，其分配到的提交频率越高，从而在总量上占据正确的字节百分比。
 		bytes := getLanguageCodeBytes(config.Language)
+		if mode == languages.ContentAppend {
+			bytes = languages.GetFragmentBytes(languages.LanguageType(config.Language))
+		}
 		weight := (config.Ratio * 10000) / bytes
 		
 		if weight < 1 {
@@ -209,6 +220,16 @@ func normalizeLanguageConfigs(languageConfigs []LanguageConfig) []LanguageConfig
 	return normalized
 }
 
+// sortedFilePaths 按路径排序返回文件列表，保证写入与提交顺序稳定。
+func sortedFilePaths(files map[string]string) []string {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
 // mergeAdditionalFiles 聚合多语言模板产生的所有额外文件。
 // 如果不同语言对同一个路径产生了冲突文件，该方法会自动为后来的语言文件添加后缀，以防相互覆盖。
 func mergeAdditionalFiles(repoName string, languageConfigs []LanguageConfig) map[string]string {
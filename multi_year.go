@@ -0,0 +1,230 @@
+// multi_year.go 实现跨年份的仓库生成：将多个年份（或任意日期区间）的贡献数据生成为一段连续的提交历史，或按年份拆分为多个仓库。
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// YearContributions 代表某一年份的贡献数据。
+type YearContributions struct {
+	Year          int               `json:"year"`          // 年份
+	Contributions []ContributionDay `json:"contributions"` // 该年份内每一天的提交数
+}
+
+// MultiYearRepoRequest 定义跨年份生成请求。
+// Years 与 Contributions 二选一：前者按年份分组，后者配合 StartDate/EndDate 描述任意日期区间。
+type MultiYearRepoRequest struct {
+	GithubUsername  string              `json:"githubUsername"`  // 提交者的 GitHub 用户名
+	GithubEmail     string              `json:"githubEmail"`     // 提交者的 GitHub 邮箱
+	RepoName        string              `json:"repoName"`        // 目标仓库名，拆分模式下作为前缀
+	StartDate       string              `json:"startDate"`       // 区间起始日期 (YYYY-MM-DD)，可选
+	EndDate         string              `json:"endDate"`         // 区间结束日期 (YYYY-MM-DD)，可选
+	Years           []YearContributions `json:"years"`           // 按年份分组的贡献数据
+	Contributions   []ContributionDay   `json:"contributions"`   // 日期区间内的贡献数据
+	SplitByYear     bool                `json:"splitByYear"`     // 是否为每个年份单独生成一个仓库
+	Language        string              `json:"language"`        // 默认编程语言(单语言模式)
+	LanguageConfigs []LanguageConfig    `json:"languageConfigs"` // 多语言配置(多语言模式)
+	MultiLanguage   bool                `json:"multiLanguage"`   // 是否启用多语言混合生成
+	// 签名、提交归属、提交说明、文件布局与提交时间等选项，与 GenerateRepoRequest 中的含义相同
+	GenerationOptions
+}
+
+// YearRepo 描述一个生成完成的本地仓库。
+type YearRepo struct {
+	Years       []int  `json:"years"`       // 仓库覆盖的年份
+	RepoName    string `json:"repoName"`    // 仓库名
+	RepoPath    string `json:"repoPath"`    // 仓库在本地的存储路径
+	CommitCount int    `json:"commitCount"` // 提交数
+	// WorkspaceName 是仓库在工作区中的项目名（同名时带数字后缀），用于重新打开、重试推送或删除
+	WorkspaceName string `json:"workspaceName"`
+}
+
+// MultiYearRepoResponse 返回跨年份生成结果。
+type MultiYearRepoResponse struct {
+	Repos       []YearRepo     `json:"repos"`       // 生成的仓库（单仓库模式下只有一个）
+	CommitCount int            `json:"commitCount"` // 所有仓库的提交总数
+	Author      CommitIdentity `json:"author"`      // 提交使用的作者身份
+}
+
+// GenerateMultiYearRepo 根据多个年份或日期区间的贡献数据生成仓库。
+// 默认生成一段连续的历史；启用 SplitByYear 时每个年份生成一个独立的仓库。
+func (a *App) GenerateMultiYearRepo(req MultiYearRepoRequest) (*MultiYearRepoResponse, error) {
+	byYear, err := groupContributionsByYear(req)
+	if err != nil {
+		LogError("跨年份贡献数据校验失败", zap.Error(err))
+		return nil, err
+	}
+
+	years := make([]int, 0, len(byYear))
+	for year := range byYear {
+		years = append(years, year)
+	}
+	sort.Ints(years)
+	if len(years) == 0 {
+		return nil, fmt.Errorf("no contributions supplied")
+	}
+
+	LogInfo("开始跨年份生成仓库",
+		zap.Ints("years", years),
+		zap.Bool("split_by_year", req.SplitByYear))
+
+	baseName := strings.TrimSpace(req.RepoName)
+	if baseName == "" {
+		baseName = strings.TrimSpace(req.GithubUsername)
+	}
+	if baseName == "" {
+		baseName = "contributions"
+	}
+
+	genReq := GenerateRepoRequest{
+		GithubUsername:    req.GithubUsername,
+		GithubEmail:       req.GithubEmail,
+		Language:          req.Language,
+		LanguageConfigs:   req.LanguageConfigs,
+		MultiLanguage:     req.MultiLanguage,
+		GenerationOptions: req.GenerationOptions,
+	}
+
+	resp := &MultiYearRepoResponse{}
+	if req.SplitByYear {
+		for _, year := range years {
+			genReq.Year = year
+			genReq.RepoName = fmt.Sprintf("%s-%d", baseName, year)
+			genReq.Contributions = byYear[year]
+			result, err := a.GenerateRepo(genReq)
+			if err != nil {
+				// 部分年份失败时删除已生成的仓库，避免在工作区中留下不完整的一组仓库
+				for _, repo := range resp.Repos {
+					if removeErr := os.RemoveAll(repo.RepoPath); removeErr != nil {
+						LogWarn("清理已生成的仓库失败", zap.String("path", repo.RepoPath), zap.Error(removeErr))
+					}
+				}
+				return nil, fmt.Errorf("generate repo for %d: %w", year, err)
+			}
+			resp.Repos = append(resp.Repos, YearRepo{
+				Years:         []int{year},
+				RepoName:      sanitiseRepoName(genReq.RepoName),
+				RepoPath:      result.RepoPath,
+				CommitCount:   result.CommitCount,
+				WorkspaceName: result.WorkspaceName,
+			})
+			resp.CommitCount += result.CommitCount
+			resp.Author = result.Author
+		}
+		return resp, nil
+	}
+
+	genReq.RepoName = strings.TrimSpace(req.RepoName)
+	if genReq.RepoName == "" {
+		genReq.RepoName = fmt.Sprintf("%s-%d", baseName, years[0])
+		if len(years) > 1 {
+			genReq.RepoName = fmt.Sprintf("%s-%d-%d", baseName, years[0], years[len(years)-1])
+		}
+	}
+	// 工作区元数据以第一个年份作为仓库年份，并记录覆盖的全部年份
+	genReq.Year = years[0]
+	for _, year := range years {
+		genReq.Contributions = append(genReq.Contributions, byYear[year]...)
+	}
+	result, err := a.GenerateRepo(genReq)
+	if err != nil {
+		return nil, err
+	}
+	if len(years) > 1 {
+		repo, err := readWorkspaceMetadata(result.RepoPath)
+		if err == nil {
+			repo.Years = years
+			err = writeWorkspaceMetadata(repo)
+		}
+		if err != nil {
+			LogWarn("记录仓库覆盖的年份失败", zap.String("path", result.RepoPath), zap.Error(err))
+		}
+	}
+	resp.Repos = []YearRepo{{
+		Years:         years,
+		RepoName:      sanitiseRepoName(genReq.RepoName),
+		RepoPath:      result.RepoPath,
+		CommitCount:   result.CommitCount,
+		WorkspaceName: result.WorkspaceName,
+	}}
+	resp.CommitCount = result.CommitCount
+	resp.Author = result.Author
+	return resp, nil
+}
+
+// groupContributionsByYear 校验跨年份请求并按年份对贡献数据分组。
+// 重复的年份、同一日期出现多次、日期不属于所在年份或超出指定区间都会被拒绝。
+func groupContributionsByYear(req MultiYearRepoRequest) (map[int][]ContributionDay, error) {
+	var start, end time.Time
+	var err error
+	if req.StartDate != "" {
+		if start, err = time.Parse("2006-01-02", req.StartDate); err != nil {
+			return nil, fmt.Errorf("invalid start date %q: %w", req.StartDate, err)
+		}
+	}
+	if req.EndDate != "" {
+		if end, err = time.Parse("2006-01-02", req.EndDate); err != nil {
+			return nil, fmt.Errorf("invalid end date %q: %w", req.EndDate, err)
+		}
+	}
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		return nil, fmt.Errorf("end date %s is before start date %s", req.EndDate, req.StartDate)
+	}
+	if len(req.Years) > 0 && len(req.Contributions) > 0 {
+		return nil, fmt.Errorf("years and contributions cannot be used together")
+	}
+
+	seen := make(map[string]bool)
+	byYear := make(map[int][]ContributionDay)
+	add := func(year int, c ContributionDay) error {
+		date, err := time.Parse("2006-01-02", c.Date)
+		if err != nil {
+			return fmt.Errorf("invalid date %q: %w", c.Date, err)
+		}
+		if year > 0 && date.Year() != year {
+			return fmt.Errorf("date %s is outside year %d", c.Date, year)
+		}
+		if (!start.IsZero() && date.Before(start)) || (!end.IsZero() && date.After(end)) {
+			return fmt.Errorf("date %s is outside range %s..%s", c.Date, req.StartDate, req.EndDate)
+		}
+		if c.Count < 0 {
+			return fmt.Errorf("invalid contribution count for %s: %d", c.Date, c.Count)
+		}
+		if seen[c.Date] {
+			return fmt.Errorf("date %s appears more than once", c.Date)
+		}
+		seen[c.Date] = true
+		if c.Count > 0 {
+			byYear[date.Year()] = append(byYear[date.Year()], c)
+		}
+		return nil
+	}
+
+	yearSeen := make(map[int]bool)
+	for _, y := range req.Years {
+		if y.Year <= 0 {
+			return nil, fmt.Errorf("invalid year: %d", y.Year)
+		}
+		if yearSeen[y.Year] {
+			return nil, fmt.Errorf("year %d appears more than once", y.Year)
+		}
+		yearSeen[y.Year] = true
+		for _, c := range y.Contributions {
+			if err := add(y.Year, c); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, c := range req.Contributions {
+		if err := add(0, c); err != nil {
+			return nil, err
+		}
+	}
+	return byYear, nil
+}
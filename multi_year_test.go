@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestGenerateMultiYearRepoSplitCleansUpOnFailure(t *testing.T) {
+	a := newTestApp(t)
+	req := MultiYearRepoRequest{
+		GithubUsername: "tester",
+		GithubEmail:    "tester@example.com",
+		RepoName:       "art",
+		SplitByYear:    true,
+		Years: []YearContributions{
+			{Year: 2023, Contributions: []ContributionDay{{Date: "2023-03-01", Count: 2}}},
+			{Year: 2024, Contributions: []ContributionDay{{Date: "2024-01-01", Count: 1}}},
+		},
+	}
+	// 模板在示例数据上可以渲染，只在 2024-01-01 引用不存在的字段而失败
+	req.CommitMessage = &CommitMessageOptions{Template: `{{if eq .Date "2024-01-01"}}{{.Missing}}{{end}}update {{.Date}}`}
+
+	if _, err := a.GenerateMultiYearRepo(req); err == nil {
+		t.Fatal("expected the 2024 repository to fail")
+	}
+	entries, err := os.ReadDir(a.repoBasePath)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		t.Errorf("workspace still contains %s", entry.Name())
+	}
+}
+
+func TestGenerateMultiYearRepoSplit(t *testing.T) {
+	a := newTestApp(t)
+	req := MultiYearRepoRequest{
+		GithubUsername: "tester",
+		GithubEmail:    "tester@example.com",
+		RepoName:       "art",
+		SplitByYear:    true,
+		Years: []YearContributions{
+			{Year: 2023, Contributions: []ContributionDay{{Date: "2023-03-01", Count: 2}}},
+			{Year: 2024, Contributions: []ContributionDay{{Date: "2024-01-01", Count: 1}}},
+		},
+	}
+	req.Seed = 7
+	resp, err := a.GenerateMultiYearRepo(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Repos) != 2 || resp.Repos[0].RepoName != "art-2023" || resp.Repos[1].RepoName != "art-2024" {
+		t.Fatalf("repos = %+v", resp.Repos)
+	}
+}
+
+// 连续历史模式下工作区元数据记录第一个年份与覆盖的全部年份，返回的项目名可以直接用于重新打开。
+func TestGenerateMultiYearRepoWorkspaceMetadata(t *testing.T) {
+	a := newTestApp(t)
+	req := MultiYearRepoRequest{
+		GithubUsername: "tester",
+		GithubEmail:    "tester@example.com",
+		RepoName:       "art",
+		Years: []YearContributions{
+			{Year: 2023, Contributions: []ContributionDay{{Date: "2023-03-01", Count: 2}}},
+			{Year: 2024, Contributions: []ContributionDay{{Date: "2024-01-01", Count: 1}}},
+		},
+	}
+	resp, err := a.GenerateMultiYearRepo(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo, err := a.loadWorkspaceRepo(resp.Repos[0].WorkspaceName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.Year != 2023 || !reflect.DeepEqual(repo.Years, []int{2023, 2024}) || repo.CommitCount != 3 {
+		t.Errorf("metadata = %+v", repo)
+	}
+
+	// 拆分模式下同名项目已存在时，项目名带数字后缀，与仓库名不同
+	req.SplitByYear = true
+	if resp, err = a.GenerateMultiYearRepo(req); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range resp.Repos {
+		repo, err := a.loadWorkspaceRepo(r.WorkspaceName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if repo.Path != r.RepoPath || repo.Year != r.Years[0] {
+			t.Errorf("%s: metadata = %+v, repo = %+v", r.WorkspaceName, repo, r)
+		}
+	}
+	req.Years = req.Years[:1]
+	if resp, err = a.GenerateMultiYearRepo(req); err != nil {
+		t.Fatal(err)
+	}
+	if r := resp.Repos[0]; r.RepoName != "art-2023" || r.WorkspaceName != "art-2023-2" {
+		t.Errorf("repo = %+v, want workspace art-2023-2", r)
+	}
+}
@@ -410,7 +410,7 @@ func (a *App) exchangeCodeForToken(code, clientID, clientSecret, redirectURI str
 func (a *App) fetchGitHubUserInfo(accessToken string) (*UserInfo, error) {
 	LogInfo("获取 GitHub 用户信息")
 	
-	req, err := http.NewRequest("GET", "https://api.github.com/user", nil)
+	req, err := http.NewRequest("GET", githubAPIBase+"/user", nil)
 	if err != nil {
 		LogError("创建用户信息请求失败", zap.Error(err))
 		return nil, fmt.Errorf("创建请求失败: %w", err)
@@ -465,55 +465,66 @@ func (a *App) fetchGitHubUserInfo(accessToken string) (*UserInfo, error) {
 	}, nil
 }
 
+// GitHubEmail 表示 /user/emails 接口返回的邮箱记录。
+type GitHubEmail struct {
+	Email    string `json:"email"`    // 邮箱地址
+	Primary  bool   `json:"primary"`  // 是否为主邮箱
+	Verified bool   `json:"verified"` // 是否已验证
+}
+
 // fetchGitHubUserEmail 获取 GitHub 用户的主邮箱（Primary Email）。
 func (a *App) fetchGitHubUserEmail(accessToken string) (string, error) {
-	req, err := http.NewRequest("GET", "https://api.github.com/user/emails", nil)
+	emails, err := a.fetchGitHubUserEmails(accessToken)
 	if err != nil {
 		return "", err
 	}
 
+	for _, e := range emails {
+		if e.Primary && e.Verified {
+			return e.Email, nil
+		}
+	}
+
+	for _, e := range emails {
+		if e.Verified {
+			return e.Email, nil
+		}
+	}
+
+	return "", fmt.Errorf("未找到可用邮箱")
+}
+
+// fetchGitHubUserEmails 获取 GitHub 用户绑定的所有邮箱及其验证状态（需要 user:email 权限）。
+func (a *App) fetchGitHubUserEmails(accessToken string) ([]GitHubEmail, error) {
+	req, err := http.NewRequest("GET", githubAPIBase+"/user/emails", nil)
+	if err != nil {
+		return nil, err
+	}
+
 	req.Header.Set("Authorization", "Bearer "+accessToken)
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 
 	client := &http.Client{Timeout: 10 * time.Second}
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("获取邮箱失败: %d", resp.StatusCode)
+		return nil, fmt.Errorf("获取邮箱失败: %d", resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
-	}
-
-	var emails []struct {
-		Email    string `json:"email"`
-		Primary  bool   `json:"primary"`
-		Verified bool   `json:"verified"`
+		return nil, err
 	}
 
+	var emails []GitHubEmail
 	if err := json.Unmarshal(body, &emails); err != nil {
-		return "", err
-	}
-
-	for _, e := range emails {
-		if e.Primary && e.Verified {
-			return e.Email, nil
-		}
+		return nil, err
 	}
-
-	for _, e := range emails {
-		if e.Verified {
-			return e.Email, nil
-		}
-	}
-
-	return "", fmt.Errorf("未找到可用邮箱")
+	return emails, nil
 }
 
 // SaveUserInfo 将用户信息持久化到磁盘，以便下次启动时保持登录。
@@ -542,25 +553,30 @@ func (a *App) LoadUserInfo() (*UserInfo, error) {
 	userInfoPath := a.getUserInfoPath()
 	LogInfo("加载用户信息", zap.String("path", userInfoPath))
 	
-	data, err := os.ReadFile(userInfoPath)
+	userInfo, err := readUserInfo(userInfoPath)
 	if err != nil {
-		if os.IsNotExist(err) {
-			LogInfo("用户信息文件不存在")
-			return nil, nil
-		}
 		LogError("读取用户信息失败", zap.Error(err))
-		return nil, fmt.Errorf("read user info: %w", err)
+		return nil, err
 	}
-	
-	var userInfo UserInfo
-	if err := json.Unmarshal(data, &userInfo); err != nil {
-		LogError("解析用户信息失败", zap.Error(err))
-		return nil, fmt.Errorf("unmarshal user info: %w", err)
+	if userInfo == nil {
+		LogInfo("用户信息文件不存在")
+		return nil, nil
 	}
-	
-	a.userInfo = &userInfo
+
+	a.userInfo = userInfo
 	LogInfo("用户信息加载成功", zap.String("username", userInfo.Username))
-	return &userInfo, nil
+	return userInfo, nil
+}
+
+// ensureUserInfo 在尚未加载登录信息时从磁盘加载，供定时器与命令行等非界面入口使用。
+// 加载过程加锁，多个滴灌计划同时运行时不会并发写入 a.userInfo。
+func (a *App) ensureUserInfo() (*UserInfo, error) {
+	a.userInfoMu.Lock()
+	defer a.userInfoMu.Unlock()
+	if a.userInfo != nil {
+		return a.userInfo, nil
+	}
+	return a.LoadUserInfo()
 }
 
 // Logout 执行退出操作：删除本地持久化文件并清除内存状态。
@@ -577,13 +593,34 @@ func (a *App) Logout() error {
 	return nil
 }
 
-// getUserInfoPath 计算用户信息存储的绝对路径。
+// getUserInfoPath 计算用户信息存储的绝对路径，并确保配置目录存在。
 func (a *App) getUserInfoPath() string {
+	userInfoPath := userInfoFilePath()
+	os.MkdirAll(filepath.Dir(userInfoPath), 0o755)
+	return userInfoPath
+}
+
+// userInfoFilePath 计算用户信息存储的绝对路径，不创建任何目录。
+func userInfoFilePath() string {
 	configDir, err := os.UserConfigDir()
 	if err != nil {
 		configDir = os.TempDir()
 	}
-	appConfigDir := filepath.Join(configDir, "green-wall")
-	os.MkdirAll(appConfigDir, 0o755)
-	return filepath.Join(appConfigDir, "user.json")
+	return filepath.Join(configDir, "green-wall", "user.json")
+}
+
+// readUserInfo 只读取保存的用户信息，不创建配置目录，也不修改 a.userInfo；文件不存在时返回 nil。
+func readUserInfo(userInfoPath string) (*UserInfo, error) {
+	data, err := os.ReadFile(userInfoPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read user info: %w", err)
+	}
+	var userInfo UserInfo
+	if err := json.Unmarshal(data, &userInfo); err != nil {
+		return nil, fmt.Errorf("unmarshal user info: %w", err)
+	}
+	return &userInfo, nil
 }
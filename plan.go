@@ -0,0 +1,440 @@
+// plan.go 计算一次仓库生成的完整计划：每个提交的时间、语言、文件与说明，以及最终的文件树。
+// GenerateRepo 边计算边把提交写入 fast-import，PlanGeneration 只返回计划，不创建目录也不修改任何仓库。
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"green-wall/templates/languages"
+)
+
+// scaffoldCommitMessage 是单独的脚手架提交的说明。
+const scaffoldCommitMessage = "Initial project scaffold"
+
+// PlannedCommit 描述计划中的一个提交。
+type PlannedCommit struct {
+	Date      string      `json:"date"`            // 贡献日期 (YYYY-MM-DD)
+	Timestamp string      `json:"timestamp"`       // 提交时间 (RFC3339)
+	Language  string      `json:"language"`        // 本次提交使用的语言，脚手架提交为空
+	FilePath  string      `json:"filePath"`        // 写入的代码文件，脚手架提交为空
+	Files     []string    `json:"files,omitempty"` // 随本提交加入的模板额外文件
+	Message   string      `json:"message"`         // 完整的提交说明，包含尾注
+	BlobSize  int         `json:"blobSize"`        // 代码文件的字节数，脚手架提交为所有额外文件之和
+	Author    GitIdentity `json:"author"`          // 作者
+	Committer GitIdentity `json:"committer"`       // 提交者
+	Scaffold  bool        `json:"scaffold"`        // 是否为脚手架提交
+
+	time  time.Time
+	index int // 当天的第几个提交，从 0 开始
+}
+
+// PlannedFile 描述生成完成后仓库中的一个文件。
+type PlannedFile struct {
+	Path     string `json:"path"`     // 相对仓库根目录的路径
+	Size     int    `json:"size"`     // 最终内容的字节数
+	Language string `json:"language"` // 活动文件所属的语言，README 与脚手架文件为空
+}
+
+// GenerationPlan 是一次生成的完整计划。
+type GenerationPlan struct {
+	RepoName      string           `json:"repoName"`      // 仓库名
+	Author        CommitIdentity   `json:"author"`        // 仓库默认的作者身份及其来源
+	CommitCount   int              `json:"commitCount"`   // 提交总数，含单独的脚手架提交
+	Commits       []PlannedCommit  `json:"commits"`       // 按提交顺序排列的提交
+	Files         []PlannedFile    `json:"files"`         // 最终文件树，按路径排序
+	LanguageBytes map[string]int   `json:"languageBytes"` // 最终文件树中每种语言活动文件的总字节数
+	AuthorCommits map[string]int   `json:"authorCommits"` // 每位作者（按邮箱）分配到的提交数
+	DeltaWarnings []ShadingWarning `json:"deltaWarnings,omitempty"`
+
+	// ScaffoldCommit 表示额外文件在 ScaffoldDate 单独提交，而不是随第一个贡献提交加入
+	ScaffoldCommit bool `json:"scaffoldCommit"`
+
+	readme   string            // README.md 内容
+	scaffold map[string]string // 模板额外文件
+}
+
+// PlanGeneration 返回按 req 生成仓库时将产生的提交与文件，不创建目录，也不修改任何仓库，
+// 便于预览或在代码评审中比较两次生成的差异。未填写作者时会读取（但不修改）保存的登录信息与全局 git 配置；
+// 预览不访问 GitHub，增量模式必须在请求中提供 ExistingContributions。
+func (a *App) PlanGeneration(req GenerateRepoRequest) (*GenerationPlan, error) {
+	plan, err := a.planGeneration(req)
+	if err != nil {
+		LogError("生成计划失败", zap.Error(err))
+		return nil, err
+	}
+	return plan, nil
+}
+
+// planGeneration 校验请求并计算完整的生成计划（不含文件内容）。
+func (a *App) planGeneration(req GenerateRepoRequest) (*GenerationPlan, error) {
+	if req.DeltaMode && len(req.ExistingContributions) == 0 {
+		return nil, fmt.Errorf("delta mode plans require existing contributions: the dry run does not fetch the contribution calendar")
+	}
+	g, err := a.newGenerationPlanner(req)
+	if err != nil {
+		return nil, err
+	}
+	err = g.run(func(commit *PlannedCommit, _ []plannedBlob) error {
+		g.plan.Commits = append(g.plan.Commits, *commit)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return g.plan, nil
+}
+
+// generationPlanner 保存校验后的生成参数。run 按时间顺序逐个产生提交，
+// 由调用方决定是收集为计划还是直接写入 fast-import 流，文件内容只保留每个文件的当前版本。
+type generationPlanner struct {
+	plan            *GenerationPlan
+	languageConfigs []LanguageConfig
+	contribs        []ContributionDay
+	fileLayout      languages.FileLayout
+	contentMode     languages.ContentMode
+	messages        *commitMessageRenderer
+	attribution     *commitAttribution
+	identity        GitIdentity
+	seed            int64
+	scaffoldDate    time.Time
+}
+
+// plannedBlob 是提交写入的一个文件，content 只在 emit 回调期间有效。
+type plannedBlob struct {
+	path    string
+	content []byte
+}
+
+// newGenerationPlanner 校验请求并解析生成所需的全部参数，GenerateRepo 与 PlanGeneration 共用。
+func (a *App) newGenerationPlanner(req GenerateRepoRequest) (*generationPlanner, error) {
+	// 处理语言配置
+	var languageConfigs []LanguageConfig
+	if req.MultiLanguage && len(req.LanguageConfigs) > 0 {
+		languageConfigs = req.LanguageConfigs
+		if err := validateLanguageConfigs(languageConfigs); err != nil {
+			return nil, fmt.Errorf("invalid language configs: %w", err)
+		}
+		languageConfigs = normalizeLanguageConfigs(languageConfigs)
+	} else {
+		// 单语言模式(向后兼容)
+		language := req.Language
+		if language == "" {
+			language = "markdown"
+		}
+		languageConfigs = []LanguageConfig{{Language: language, Ratio: 100}}
+	}
+
+	plan := &GenerationPlan{LanguageBytes: make(map[string]int)}
+	if req.DeltaMode {
+		delta, err := a.ComputeDeltaContributions(DeltaContributionsRequest{
+			Year:     req.Year,
+			Target:   req.Contributions,
+			Existing: req.ExistingContributions,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("compute delta contributions: %w", err)
+		}
+		req.Contributions = delta.Contributions
+		plan.DeltaWarnings = delta.Warnings
+		if len(plan.DeltaWarnings) > 0 {
+			LogWarn("部分格子无法呈现目标色阶", zap.Int("count", len(plan.DeltaWarnings)))
+		}
+	}
+
+	if len(req.Contributions) == 0 {
+		return nil, fmt.Errorf("no contributions supplied")
+	}
+	totalRequestedCommits := 0
+	for _, c := range req.Contributions {
+		if c.Count < 0 {
+			return nil, fmt.Errorf("invalid contribution count for %s: %d", c.Date, c.Count)
+		}
+		totalRequestedCommits += c.Count
+	}
+	if totalRequestedCommits == 0 {
+		return nil, fmt.Errorf("no commits to generate")
+	}
+
+	// 多作者模式下以第一位作者作为仓库的默认身份
+	var author *CommitIdentity
+	var err error
+	if len(req.Authors) > 0 {
+		author = &CommitIdentity{
+			Name:        strings.TrimSpace(req.Authors[0].Name),
+			Email:       strings.TrimSpace(req.Authors[0].Email),
+			NameSource:  identitySourceRequest,
+			EmailSource: identitySourceRequest,
+		}
+	} else if author, err = a.resolveCommitIdentity(req.GithubUsername, req.GithubEmail); err != nil {
+		return nil, err
+	}
+	plan.Author = *author
+	defaultIdentity := GitIdentity{Name: author.Name, Email: author.Email}
+	attribution, err := newCommitAttribution(defaultIdentity, req.Authors, req.Committer, req.CoAuthors)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attribution: %w", err)
+	}
+	plan.AuthorCommits = attribution.counts
+
+	if err := validateSigningOptions(req.Signing); err != nil {
+		return nil, err
+	}
+	fileLayout, err := languages.ParseFileLayout(req.FileLayout)
+	if err != nil {
+		return nil, err
+	}
+	contentMode, err := languages.ParseContentMode(req.ContentMode)
+	if err != nil {
+		return nil, err
+	}
+	messages, err := newCommitMessageRenderer(req.CommitMessage)
+	if err != nil {
+		return nil, err
+	}
+	var scaffoldDate time.Time
+	if req.ScaffoldDate != "" {
+		if scaffoldDate, err = time.Parse("2006-01-02", req.ScaffoldDate); err != nil {
+			return nil, fmt.Errorf("invalid scaffold date %q: %w", req.ScaffoldDate, err)
+		}
+	}
+
+	repoName := strings.TrimSpace(req.RepoName)
+	if repoName == "" {
+		repoName = author.Name
+		if req.Year > 0 {
+			repoName = fmt.Sprintf("%s-%d", repoName, req.Year)
+		}
+	}
+	repoName = sanitiseRepoName(repoName)
+	if repoName == "" {
+		repoName = "contributions"
+	}
+	plan.RepoName = repoName
+	plan.CommitCount = totalRequestedCommits
+	plan.readme = generateMultiLanguageReadme(repoName, languageConfigs, fileLayout)
+	plan.scaffold = mergeAdditionalFiles(repoName, languageConfigs)
+
+	// 按日期升序排序贡献以生成时间线历史
+	contribs := make([]ContributionDay, 0, len(req.Contributions))
+	for _, c := range req.Contributions {
+		if c.Count > 0 {
+			contribs = append(contribs, c)
+		}
+	}
+	sort.SliceStable(contribs, func(i, j int) bool { return contribs[i].Date < contribs[j].Date })
+
+	// 单独的脚手架提交必须落在绘制的年份之前，否则会改变某个格子的提交数
+	if !scaffoldDate.IsZero() {
+		firstDate, err := time.Parse("2006-01-02", contribs[0].Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q: %w", contribs[0].Date, err)
+		}
+		if scaffoldDate.Year() >= firstDate.Year() {
+			return nil, fmt.Errorf("scaffold date %s must be before %d so the scaffold commit stays outside the drawing", req.ScaffoldDate, firstDate.Year())
+		}
+		if len(plan.scaffold) > 0 {
+			plan.ScaffoldCommit = true
+			plan.CommitCount++
+		}
+	}
+
+	return &generationPlanner{
+		plan:            plan,
+		languageConfigs: languageConfigs,
+		contribs:        contribs,
+		fileLayout:      fileLayout,
+		contentMode:     contentMode,
+		messages:        messages,
+		attribution:     attribution,
+		identity:        defaultIdentity,
+		seed:            req.Seed,
+		scaffoldDate:    scaffoldDate,
+	}, nil
+}
+
+// run 按提交顺序调用 emit，最后填充计划的文件树与语言统计。
+// 追加类布局与增量模式下每个活动文件只保留一份当前内容，提交之间原地追加。
+func (g *generationPlanner) run(emit func(commit *PlannedCommit, blobs []plannedBlob) error) error {
+	plan, contribs := g.plan, g.contribs
+
+	// 模板额外文件一次性纳入历史，检出后不会留下未跟踪的文件：默认随第一个贡献提交加入，
+	// 指定 ScaffoldDate 时在该日期单独提交
+	scaffoldFiles := sortedFilePaths(plan.scaffold)
+	scaffoldBlobs := make([]plannedBlob, 0, len(scaffoldFiles))
+	for _, filePath := range scaffoldFiles {
+		scaffoldBlobs = append(scaffoldBlobs, plannedBlob{path: filePath, content: []byte(plan.scaffold[filePath])})
+	}
+	if plan.ScaffoldCommit {
+		scaffoldTime := commitTimes(g.scaffoldDate, 1, g.seed)[0]
+		committer := g.identity
+		if g.attribution.committer != nil {
+			committer = *g.attribution.committer
+		}
+		scaffold := PlannedCommit{
+			Date:      scaffoldTime.Format("2006-01-02"),
+			Timestamp: scaffoldTime.Format(time.RFC3339),
+			Files:     scaffoldFiles,
+			Message:   scaffoldCommitMessage,
+			Author:    g.identity,
+			Committer: committer,
+			Scaffold:  true,
+			time:      scaffoldTime,
+		}
+		for _, blob := range scaffoldBlobs {
+			scaffold.BlobSize += len(blob.content)
+		}
+		g.attribution.counts[g.identity.Email]++
+		if err := emit(&scaffold, scaffoldBlobs); err != nil {
+			return err
+		}
+		scaffoldFiles, scaffoldBlobs = nil, nil
+	}
+
+	fileContents := make(map[string][]byte)
+	fileLanguages := make(map[string]string)
+	number := 0
+	for _, day := range contribs {
+		parsedDate, err := time.Parse("2006-01-02", day.Date)
+		if err != nil {
+			return fmt.Errorf("invalid date %q: %w", day.Date, err)
+		}
+		times := commitTimes(parsedDate, day.Count, g.seed)
+		for i := 0; i < day.Count; i++ {
+			// 根据比例选择语言
+			selectedLang := selectLanguageByRatio(g.languageConfigs, number, g.contentMode)
+			lang := languages.LanguageType(selectedLang)
+			template := languages.GetLanguageTemplate(lang)
+			codeFilePath := languages.GetLayoutFilePath(lang, g.fileLayout, day.Date)
+
+			// 使用语言模板生成代码内容：增量模式只追加片段，追加类布局保留文件已有内容
+			content := fileContents[codeFilePath]
+			switch {
+			case g.contentMode == languages.ContentAppend:
+				content = languages.AppendFragment(content, lang, day.Date, i+1, day.Count)
+			case g.fileLayout.Appends():
+				if len(content) > 0 {
+					content = append(bytes.TrimRight(content, "\n"), "\n\n"...)
+				}
+				content = append(content, template.GenerateCode(day.Date, i+1, day.Count)...)
+			default:
+				content = append(content[:0], template.GenerateCode(day.Date, i+1, day.Count)...)
+			}
+			fileContents[codeFilePath] = content
+			fileLanguages[codeFilePath] = selectedLang
+
+			commitTime := times[i]
+			commitAuthor, committer := g.attribution.next(day.Date)
+			number++
+			subject, err := g.messages.render(CommitMessageData{
+				Date:     day.Date,
+				Time:     commitTime,
+				Index:    i + 1,
+				Count:    day.Count,
+				Number:   number,
+				Language: selectedLang,
+			})
+			if err != nil {
+				return err
+			}
+			commit := PlannedCommit{
+				Date:      day.Date,
+				Timestamp: commitTime.Format(time.RFC3339),
+				Language:  selectedLang,
+				FilePath:  codeFilePath,
+				Files:     scaffoldFiles,
+				Message:   g.attribution.message(subject, commitAuthor),
+				BlobSize:  len(content),
+				Author:    commitAuthor,
+				Committer: committer,
+				time:      commitTime,
+				index:     i,
+			}
+			blobs := append(scaffoldBlobs, plannedBlob{path: codeFilePath, content: content})
+			if err := emit(&commit, blobs); err != nil {
+				return err
+			}
+			scaffoldFiles, scaffoldBlobs = nil, nil
+		}
+	}
+
+	// 最终文件树：README、脚手架文件与各活动文件的最后内容
+	tree := map[string]PlannedFile{"README.md": {Path: "README.md", Size: len(plan.readme)}}
+	for filePath, content := range plan.scaffold {
+		tree[filePath] = PlannedFile{Path: filePath, Size: len(content)}
+	}
+	for filePath, content := range fileContents {
+		tree[filePath] = PlannedFile{Path: filePath, Size: len(content), Language: fileLanguages[filePath]}
+		plan.LanguageBytes[fileLanguages[filePath]] += len(content)
+	}
+	for _, file := range tree {
+		plan.Files = append(plan.Files, file)
+	}
+	sort.Slice(plan.Files, func(i, j int) bool { return plan.Files[i].Path < plan.Files[j].Path })
+	return nil
+}
+
+// writeFastImport 边计算边写出 git fast-import 流：README 为 blob :1，
+// 每个提交更新 README 与一个代码文件，额外文件随第一个提交或单独的脚手架提交加入。
+// 每个文件版本在产生时立即写出，不在内存中保留历史版本。
+func (g *generationPlanner) writeFastImport(w io.Writer, branch string) error {
+	_, err := g.writeFastImportFrom(w, branch, "", nil)
+	return err
+}
+
+// writeFastImportFrom 与 writeFastImport 相同，但只写出 include 返回 true 的提交（include 为 nil 时全部写出），
+// 第一个写出的提交以 parent 为父提交（parent 非空时）。被跳过的提交仍参与计算，
+// 写出的文件内容、时间与说明和一次性生成时完全一致。返回写出的提交。
+func (g *generationPlanner) writeFastImportFrom(w io.Writer, branch, parent string, include func(commit *PlannedCommit) bool) ([]PlannedCommit, error) {
+	bw := bufio.NewWriter(w)
+	readme := g.plan.readme
+	if _, err := fmt.Fprintf(bw, "blob\nmark :1\ndata %d\n%s\n", len(readme), readme); err != nil {
+		return nil, err
+	}
+
+	nextMark := 2
+	var written []PlannedCommit
+	err := g.run(func(commit *PlannedCommit, blobs []plannedBlob) error {
+		if include != nil && !include(commit) {
+			return nil
+		}
+		marks := make([]int, len(blobs))
+		for i, blob := range blobs {
+			marks[i] = nextMark
+			nextMark++
+			fmt.Fprintf(bw, "blob\nmark :%d\ndata %d\n", marks[i], len(blob.content))
+			bw.Write(blob.content)
+			bw.WriteByte('\n')
+		}
+
+		secs, tz := commit.time.Unix(), commit.time.Format("-0700")
+		fmt.Fprintf(bw, "commit %s\n", branch)
+		fmt.Fprintf(bw, "author %s <%s> %d %s\n", commit.Author.Name, commit.Author.Email, secs, tz)
+		fmt.Fprintf(bw, "committer %s <%s> %d %s\n", commit.Committer.Name, commit.Committer.Email, secs, tz)
+		fmt.Fprintf(bw, "data %d\n%s\n", len(commit.Message), commit.Message)
+		if len(written) == 0 && parent != "" {
+			fmt.Fprintf(bw, "from %s\n", parent)
+		}
+		written = append(written, *commit)
+		fmt.Fprintf(bw, "M 100644 :1 README.md\n")
+		for i, blob := range blobs {
+			if _, err := fmt.Fprintf(bw, "M 100644 :%d %s\n", marks[i], blob.path); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := bw.WriteString("done\n"); err != nil {
+		return nil, err
+	}
+	return written, bw.Flush()
+}
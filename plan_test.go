@@ -0,0 +1,223 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// 生成的仓库与计划一致：每个提交写入的文件大小、最终文件树都与 PlanGeneration 的结果相同。
+func TestGenerateRepoMatchesPlan(t *testing.T) {
+	for _, tt := range []struct{ layout, contentMode string }{
+		{layout: "append"},
+		{layout: "daily"},
+		{layout: "monthly"},
+		{layout: "single", contentMode: "append"},
+	} {
+		t.Run(tt.layout+"/"+tt.contentMode, func(t *testing.T) {
+			a := newTestApp(t)
+			req := GenerateRepoRequest{
+				Year:           2024,
+				GithubUsername: "tester",
+				GithubEmail:    "tester@example.com",
+				RepoName:       "planned",
+				MultiLanguage:  true,
+				LanguageConfigs: []LanguageConfig{
+					{Language: "go", Ratio: 60},
+					{Language: "python", Ratio: 40},
+				},
+				Contributions: []ContributionDay{
+					{Date: "2024-01-02", Count: 3},
+					{Date: "2024-01-03", Count: 2},
+					{Date: "2024-02-10", Count: 4},
+				},
+			}
+			req.FileLayout, req.ContentMode, req.Seed = tt.layout, tt.contentMode, 11
+
+			plan, err := a.PlanGeneration(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := a.GenerateRepo(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			revList, err := a.runGitOutput(resp.RepoPath, "rev-list", "--reverse", "main")
+			if err != nil {
+				t.Fatal(err)
+			}
+			revs := strings.Fields(revList)
+			if len(revs) != len(plan.Commits) {
+				t.Fatalf("main has %d commits, plan has %d", len(revs), len(plan.Commits))
+			}
+			for i, commit := range plan.Commits {
+				if commit.FilePath == "" {
+					continue
+				}
+				size, err := a.runGitOutput(resp.RepoPath, "cat-file", "-s", revs[i]+":"+commit.FilePath)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if size != strconv.Itoa(commit.BlobSize) {
+					t.Errorf("commit %d %s: blob size %s, plan says %d", i, commit.FilePath, size, commit.BlobSize)
+				}
+			}
+			for _, file := range plan.Files {
+				size, err := a.runGitOutput(resp.RepoPath, "cat-file", "-s", "main:"+file.Path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if size != strconv.Itoa(file.Size) {
+					t.Errorf("%s: size %s, plan says %d", file.Path, size, file.Size)
+				}
+			}
+		})
+	}
+}
+
+// 模板额外文件默认随第一个贡献提交加入：提交总数与贡献数一致，第一天的提交数不变，工作区保持干净。
+func TestGenerateRepoScaffold(t *testing.T) {
+	contributions := []ContributionDay{
+		{Date: "2024-01-02", Count: 3},
+		{Date: "2024-02-10", Count: 2},
+	}
+	tests := []struct {
+		name         string
+		scaffoldDate string
+		wantCommits  int
+		wantScaffold bool
+		wantFirst    string
+	}{
+		{name: "folded", wantCommits: 5, wantFirst: "2024-01-02"},
+		{name: "separate", scaffoldDate: "2023-12-30", wantCommits: 6, wantScaffold: true, wantFirst: "2023-12-30"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestApp(t)
+			req := GenerateRepoRequest{
+				Year:           2024,
+				GithubUsername: "tester",
+				GithubEmail:    "tester@example.com",
+				RepoName:       "scaffold",
+				Language:       "go",
+				Contributions:  contributions,
+			}
+			req.ScaffoldDate = tt.scaffoldDate
+			resp, err := a.GenerateRepo(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.CommitCount != tt.wantCommits || resp.ScaffoldCommit != tt.wantScaffold {
+				t.Errorf("commit count = %d, scaffold commit = %v; want %d, %v",
+					resp.CommitCount, resp.ScaffoldCommit, tt.wantCommits, tt.wantScaffold)
+			}
+
+			dates, err := a.runGitOutput(resp.RepoPath, "log", "--reverse", "--format=%ad", "--date=short", "main")
+			if err != nil {
+				t.Fatal(err)
+			}
+			perDay := make(map[string]int)
+			for _, date := range strings.Fields(dates) {
+				perDay[date]++
+			}
+			if total := len(strings.Fields(dates)); total != resp.CommitCount {
+				t.Errorf("main has %d commits, response reports %d", total, resp.CommitCount)
+			}
+			for _, c := range contributions {
+				if perDay[c.Date] != c.Count {
+					t.Errorf("%s has %d commits, want %d", c.Date, perDay[c.Date], c.Count)
+				}
+			}
+			if first := strings.Fields(dates)[0]; first != tt.wantFirst {
+				t.Errorf("first commit on %s, want %s", first, tt.wantFirst)
+			}
+
+			status, err := a.runGitOutput(resp.RepoPath, "status", "--porcelain", "--untracked-files=all")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status != "" {
+				t.Errorf("working tree is not clean after generation:\n%s", status)
+			}
+			for _, file := range []string{"go.mod", ".gitignore", "README.md"} {
+				if _, err := a.runGitOutput(resp.RepoPath, "cat-file", "-e", "main:"+file); err != nil {
+					t.Errorf("%s is not committed: %v", file, err)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateRepoScaffoldDateInsideDrawing(t *testing.T) {
+	a := newTestApp(t)
+	req := GenerateRepoRequest{
+		Year:           2024,
+		GithubUsername: "tester",
+		GithubEmail:    "tester@example.com",
+		Language:       "go",
+		Contributions:  []ContributionDay{{Date: "2024-03-01", Count: 1}},
+	}
+	req.ScaffoldDate = "2024-01-01"
+	if _, err := a.PlanGeneration(req); err == nil || !strings.Contains(err.Error(), "must be before 2024") {
+		t.Fatalf("error = %v, want scaffold date outside the drawing", err)
+	}
+}
+
+// 预览只读取保存的登录信息与全局 git 配置：不创建配置目录、不缓存登录信息，也不访问 GitHub。
+func TestPlanGenerationHasNoSideEffects(t *testing.T) {
+	a := newTestApp(t)
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gitConfig := "[user]\n\tname = Config User\n\temail = config@example.com\n"
+	if err := os.WriteFile(filepath.Join(home, ".gitconfig"), []byte(gitConfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	configDir := filepath.Dir(userInfoFilePath())
+	req := GenerateRepoRequest{
+		Year:          2024,
+		Contributions: []ContributionDay{{Date: "2024-03-01", Count: 1}},
+	}
+
+	plan, err := a.PlanGeneration(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Author.Email != "config@example.com" || plan.Author.EmailSource != identitySourceGitConfig {
+		t.Errorf("author = %+v, want git config identity", plan.Author)
+	}
+	if _, err := os.Stat(configDir); !os.IsNotExist(err) {
+		t.Errorf("dry run created %s (stat error %v)", configDir, err)
+	}
+
+	// 保存的登录信息优先于 git 配置，但只读取不缓存
+	if err := os.MkdirAll(configDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	userInfo := `{"username": "octo", "email": "octo@example.com"}`
+	if err := os.WriteFile(userInfoFilePath(), []byte(userInfo), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if plan, err = a.PlanGeneration(req); err != nil {
+		t.Fatal(err)
+	}
+	if plan.Author.Name != "octo" || plan.Author.NameSource != identitySourceGitHub {
+		t.Errorf("author = %+v, want saved login", plan.Author)
+	}
+	if a.userInfo != nil {
+		t.Errorf("dry run cached the saved login: %+v", a.userInfo)
+	}
+
+	// 增量模式的预览不会去 GitHub 获取已有贡献
+	req.DeltaMode = true
+	if _, err := a.PlanGeneration(req); err == nil || !strings.Contains(err.Error(), "existing contributions") {
+		t.Fatalf("delta plan without existing contributions: error = %v", err)
+	}
+	req.ExistingContributions = []ContributionDay{{Date: "2024-02-01", Count: 3}}
+	if _, err := a.PlanGeneration(req); err != nil {
+		t.Fatal(err)
+	}
+}
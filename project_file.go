@@ -0,0 +1,425 @@
+// project_file.go 定义带版本号的 .greenwall 项目文件格式，负责保存、加载、旧格式迁移与严格校验。
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"go.uber.org/zap"
+	"green-wall/templates/languages"
+)
+
+// projectSchemaVersion 是当前项目文件格式的版本号。
+// 版本 0 表示早期导出的纯贡献数组（[]ContributionDay），加载时会自动迁移。
+// 生成选项中的签名、提交归属、提交说明等字段均为可选字段，加入时不需要提升版本号。
+const projectSchemaVersion = 1
+
+// projectFileExtension 是项目文件的扩展名。
+const projectFileExtension = ".greenwall"
+
+// ProjectAuthor 记录生成提交时使用的作者身份。
+type ProjectAuthor struct {
+	Name  string `json:"name"`  // 作者名
+	Email string `json:"email"` // 作者邮箱
+}
+
+// ProjectGenerationOptions 记录生成仓库时使用的选项。
+type ProjectGenerationOptions struct {
+	Language      string `json:"language"`      // 默认编程语言(单语言模式)
+	MultiLanguage bool   `json:"multiLanguage"` // 是否启用多语言混合生成
+	DeltaMode     bool   `json:"deltaMode"`     // 是否只生成在已有贡献基础上所需的额外提交
+	// 与 GenerateRepoRequest 相同的签名、提交归属、提交说明、文件布局与提交时间等选项
+	GenerationOptions
+}
+
+// ProjectDocument 是 .greenwall 项目文件的完整内容。
+type ProjectDocument struct {
+	SchemaVersion   int                      `json:"schemaVersion"`   // 文件格式版本
+	Year            int                      `json:"year"`            // 目标年份
+	RepoName        string                   `json:"repoName"`        // 目标仓库名
+	Author          ProjectAuthor            `json:"author"`          // 提交作者
+	Timezone        string                   `json:"timezone"`        // IANA 时区名，为空表示 UTC
+	Contributions   []ContributionDay        `json:"contributions"`   // 每一天的提交数
+	LanguageConfigs []LanguageConfig         `json:"languageConfigs"` // 多语言配置
+	Options         ProjectGenerationOptions `json:"options"`         // 生成选项
+}
+
+// ProjectValidationError 描述项目文件中某个字段的校验错误。
+type ProjectValidationError struct {
+	Path    string `json:"path"`    // JSON 路径，如 $.contributions[3].date
+	Message string `json:"message"` // 错误原因
+}
+
+// ProjectFileError 汇总项目文件的所有校验错误。
+type ProjectFileError struct {
+	Errors []ProjectValidationError
+}
+
+func (e *ProjectFileError) Error() string {
+	parts := make([]string, 0, len(e.Errors))
+	for _, v := range e.Errors {
+		parts = append(parts, fmt.Sprintf("%s: %s", v.Path, v.Message))
+	}
+	return "invalid project file: " + strings.Join(parts, "; ")
+}
+
+// SaveProjectRequest 定义保存项目文件的请求。
+type SaveProjectRequest struct {
+	Project ProjectDocument `json:"project"`
+}
+
+// SaveProjectResponse 定义保存结果。
+type SaveProjectResponse struct {
+	Success  bool                     `json:"success"`          // 是否成功
+	Message  string                   `json:"message"`          // 详细信息
+	FilePath string                   `json:"filePath"`         // 保存的文件路径
+	Errors   []ProjectValidationError `json:"errors,omitempty"` // 校验失败的字段
+}
+
+// LoadProjectResponse 定义加载结果。
+type LoadProjectResponse struct {
+	Project  *ProjectDocument         `json:"project"`          // 加载并迁移后的项目
+	FilePath string                   `json:"filePath"`         // 文件路径
+	Migrated bool                     `json:"migrated"`         // 是否从旧格式迁移而来
+	Errors   []ProjectValidationError `json:"errors,omitempty"` // 校验失败的字段，非空时 Project 为 nil
+}
+
+// SaveProject 校验项目内容并通过保存对话框写入 .greenwall 文件。
+func (a *App) SaveProject(req SaveProjectRequest) (*SaveProjectResponse, error) {
+	LogInfo("开始保存项目文件", zap.String("repo_name", req.Project.RepoName))
+
+	doc := req.Project
+	doc.SchemaVersion = projectSchemaVersion
+	if errs := validateProject(&doc); len(errs) > 0 {
+		LogWarn("项目校验失败", zap.Int("errors", len(errs)))
+		return &SaveProjectResponse{Success: false, Message: "项目内容校验失败", Errors: errs}, nil
+	}
+
+	data, err := encodeProject(&doc)
+	if err != nil {
+		LogError("序列化项目失败", zap.Error(err))
+		return nil, fmt.Errorf("marshal project: %w", err)
+	}
+
+	defaultName := sanitiseRepoName(doc.RepoName)
+	if defaultName == "" {
+		defaultName = "project"
+	}
+	filePath, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "保存项目",
+		DefaultFilename: defaultName + projectFileExtension,
+		Filters: []runtime.FileFilter{
+			{DisplayName: "GreenWall 项目 (*.greenwall)", Pattern: "*" + projectFileExtension},
+		},
+	})
+	if err != nil {
+		LogError("保存文件对话框失败", zap.Error(err))
+		return nil, fmt.Errorf("save file dialog: %w", err)
+	}
+	if filePath == "" {
+		LogInfo("用户取消了保存操作")
+		return &SaveProjectResponse{Success: false, Message: "用户取消了保存操作"}, nil
+	}
+	if filepath.Ext(filePath) == "" {
+		filePath += projectFileExtension
+	}
+
+	if err := os.WriteFile(filePath, data, 0o644); err != nil {
+		LogError("写入项目文件失败", zap.String("path", filePath), zap.Error(err))
+		return nil, fmt.Errorf("write project file: %w", err)
+	}
+
+	LogInfo("保存项目文件成功", zap.String("path", filePath))
+	return &SaveProjectResponse{Success: true, Message: "保存成功", FilePath: filePath}, nil
+}
+
+// LoadProject 通过打开对话框读取项目文件，旧版纯贡献数组文件会被自动迁移为当前格式。
+func (a *App) LoadProject() (*LoadProjectResponse, error) {
+	LogInfo("开始加载项目文件")
+
+	filePath, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "打开项目",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "GreenWall 项目 (*.greenwall, *.json)", Pattern: "*.greenwall;*.json"},
+		},
+	})
+	if err != nil {
+		LogError("打开文件对话框失败", zap.Error(err))
+		return nil, fmt.Errorf("open file dialog: %w", err)
+	}
+	if filePath == "" {
+		LogInfo("用户取消了加载操作")
+		return nil, fmt.Errorf("load cancelled")
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		LogError("读取项目文件失败", zap.String("path", filePath), zap.Error(err))
+		return nil, fmt.Errorf("read project file: %w", err)
+	}
+
+	doc, migrated, err := decodeProject(data)
+	if err != nil {
+		var fileErr *ProjectFileError
+		if errors.As(err, &fileErr) {
+			LogWarn("项目文件校验失败", zap.String("path", filePath), zap.Int("errors", len(fileErr.Errors)))
+			return &LoadProjectResponse{FilePath: filePath, Errors: fileErr.Errors}, nil
+		}
+		LogError("解析项目文件失败", zap.Error(err))
+		return nil, err
+	}
+
+	LogInfo("加载项目文件成功",
+		zap.String("path", filePath),
+		zap.Bool("migrated", migrated),
+		zap.Int("contributions", len(doc.Contributions)))
+	return &LoadProjectResponse{Project: doc, FilePath: filePath, Migrated: migrated}, nil
+}
+
+// encodeProject 将项目序列化为带缩进的 JSON，贡献数据按日期排序以便于版本对比。
+func encodeProject(doc *ProjectDocument) ([]byte, error) {
+	out := *doc
+	out.Contributions = make([]ContributionDay, len(doc.Contributions))
+	copy(out.Contributions, doc.Contributions)
+	sort.SliceStable(out.Contributions, func(i, j int) bool { return out.Contributions[i].Date < out.Contributions[j].Date })
+	return json.MarshalIndent(out, "", "  ")
+}
+
+// decodeProject 解析项目文件内容。纯贡献数组会被迁移为当前版本；
+// 未知字段、类型错误与内容错误都会以 JSON 路径的形式一并报告。
+func decodeProject(data []byte) (*ProjectDocument, bool, error) {
+	var raw interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&raw); err != nil {
+		return nil, false, &ProjectFileError{Errors: []ProjectValidationError{{Path: "$", Message: err.Error()}}}
+	}
+
+	migrated := false
+	if arr, ok := raw.([]interface{}); ok {
+		raw = migrateBareContributions(arr)
+		migrated = true
+	}
+
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, false, &ProjectFileError{Errors: []ProjectValidationError{{Path: "$", Message: "expected a project object"}}}
+	}
+	version, _ := obj["schemaVersion"].(json.Number)
+	if v, err := version.Int64(); err == nil && v > projectSchemaVersion {
+		return nil, false, fmt.Errorf("project schema version %d is newer than supported version %d", v, projectSchemaVersion)
+	}
+
+	if errs := checkJSONShape(raw, reflect.TypeOf(ProjectDocument{}), "$"); len(errs) > 0 {
+		return nil, migrated, &ProjectFileError{Errors: errs}
+	}
+
+	normalised, err := json.Marshal(raw)
+	if err != nil {
+		return nil, migrated, fmt.Errorf("marshal project: %w", err)
+	}
+	var doc ProjectDocument
+	if err := json.Unmarshal(normalised, &doc); err != nil {
+		return nil, migrated, &ProjectFileError{Errors: []ProjectValidationError{{Path: "$", Message: err.Error()}}}
+	}
+
+	if errs := validateProject(&doc); len(errs) > 0 {
+		return nil, migrated, &ProjectFileError{Errors: errs}
+	}
+	doc.SchemaVersion = projectSchemaVersion
+	return &doc, migrated, nil
+}
+
+// migrateBareContributions 将旧版导出的纯贡献数组包装为当前版本的项目对象。
+func migrateBareContributions(arr []interface{}) map[string]interface{} {
+	year := 0
+	for _, item := range arr {
+		if m, ok := item.(map[string]interface{}); ok {
+			if date, ok := m["date"].(string); ok {
+				if t, err := time.Parse("2006-01-02", date); err == nil && (year == 0 || t.Year() < year) {
+					year = t.Year()
+				}
+			}
+		}
+	}
+	return map[string]interface{}{
+		"schemaVersion": json.Number(fmt.Sprint(projectSchemaVersion)),
+		"year":          json.Number(fmt.Sprint(year)),
+		"contributions": arr,
+	}
+}
+
+// jsonFields 收集结构体的 json 字段名及其类型，嵌入的结构体字段与 encoding/json 一样平铺。
+func jsonFields(t reflect.Type, fields map[string]reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			jsonFields(field.Type, fields)
+			continue
+		}
+		if name != "" && name != "-" {
+			fields[name] = field.Type
+		}
+	}
+}
+
+// checkJSONShape 依据目标结构体的 json 标签递归检查原始 JSON，报告未知字段与类型不匹配。
+func checkJSONShape(value interface{}, t reflect.Type, path string) []ProjectValidationError {
+	if value == nil {
+		return nil
+	}
+
+	var errs []ProjectValidationError
+	mismatch := func(expected string) []ProjectValidationError {
+		return []ProjectValidationError{{Path: path, Message: "expected " + expected}}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return mismatch("object")
+		}
+		fields := make(map[string]reflect.Type, t.NumField())
+		jsonFields(t, fields)
+		keys := make([]string, 0, len(obj))
+		for key := range obj {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fieldType, known := fields[key]
+			if !known {
+				errs = append(errs, ProjectValidationError{Path: path + "." + key, Message: "unknown field"})
+				continue
+			}
+			errs = append(errs, checkJSONShape(obj[key], fieldType, path+"."+key)...)
+		}
+	case reflect.Ptr:
+		return checkJSONShape(value, t.Elem(), path)
+	case reflect.Slice:
+		arr, ok := value.([]interface{})
+		if !ok {
+			return mismatch("array")
+		}
+		for i, item := range arr {
+			errs = append(errs, checkJSONShape(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			return mismatch("string")
+		}
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			return mismatch("boolean")
+		}
+	case reflect.Int, reflect.Int64:
+		n, ok := value.(json.Number)
+		if !ok {
+			return mismatch("integer")
+		}
+		if _, err := n.Int64(); err != nil {
+			return mismatch("integer")
+		}
+	}
+	return errs
+}
+
+// validateProject 检查项目内容的语义合法性。
+func validateProject(doc *ProjectDocument) []ProjectValidationError {
+	var errs []ProjectValidationError
+	add := func(path, format string, args ...interface{}) {
+		errs = append(errs, ProjectValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if doc.SchemaVersion != projectSchemaVersion {
+		add("$.schemaVersion", "unsupported schema version %d", doc.SchemaVersion)
+	}
+	if doc.Year < 0 {
+		add("$.year", "invalid year %d", doc.Year)
+	}
+	if doc.Timezone != "" {
+		if _, err := time.LoadLocation(doc.Timezone); err != nil {
+			add("$.timezone", "unknown timezone %q", doc.Timezone)
+		}
+	}
+	if doc.Author.Email != "" {
+		if _, err := mail.ParseAddress(doc.Author.Email); err != nil {
+			add("$.author.email", "invalid email %q", doc.Author.Email)
+		}
+	}
+
+	seen := make(map[string]int, len(doc.Contributions))
+	for i, c := range doc.Contributions {
+		path := fmt.Sprintf("$.contributions[%d]", i)
+		t, err := time.Parse("2006-01-02", c.Date)
+		if err != nil {
+			add(path+".date", "invalid date %q, expected YYYY-MM-DD", c.Date)
+		} else if doc.Year > 0 && t.Year() != doc.Year {
+			add(path+".date", "date %s is outside year %d", c.Date, doc.Year)
+		}
+		if prev, dup := seen[c.Date]; dup {
+			add(path+".date", "duplicate of $.contributions[%d]", prev)
+		} else {
+			seen[c.Date] = i
+		}
+		if c.Count < 0 {
+			add(path+".count", "negative count %d", c.Count)
+		}
+	}
+
+	known := make(map[string]bool)
+	for _, lang := range languages.GetAllLanguages() {
+		known[string(lang)] = true
+	}
+	if doc.Options.Language != "" && !known[strings.ToLower(doc.Options.Language)] {
+		add("$.options.language", "unsupported language %q", doc.Options.Language)
+	}
+	for i, cfg := range doc.LanguageConfigs {
+		path := fmt.Sprintf("$.languageConfigs[%d]", i)
+		if !known[strings.ToLower(cfg.Language)] {
+			add(path+".language", "unsupported language %q", cfg.Language)
+		}
+		if cfg.Ratio < 0 || cfg.Ratio > 100 {
+			add(path+".ratio", "ratio %d is outside 0-100", cfg.Ratio)
+		}
+	}
+
+	opts := doc.Options.GenerationOptions
+	if err := validateSigningOptions(opts.Signing); err != nil {
+		add("$.options.signing", "%v", err)
+	}
+	if len(opts.Authors) > 0 || opts.Committer != nil || len(opts.CoAuthors) > 0 {
+		// 实际生成时 authors 为空才会使用默认身份，这里用占位身份校验归属选项
+		placeholder := GitIdentity{Name: "GreenWall", Email: "greenwall@example.com"}
+		if _, err := newCommitAttribution(placeholder, opts.Authors, opts.Committer, opts.CoAuthors); err != nil {
+			add("$.options", "%v", err)
+		}
+	}
+	if _, err := newCommitMessageRenderer(opts.CommitMessage); err != nil {
+		add("$.options.commitMessage", "%v", err)
+	}
+	if _, err := languages.ParseFileLayout(opts.FileLayout); err != nil {
+		add("$.options.fileLayout", "%v", err)
+	}
+	if _, err := languages.ParseContentMode(opts.ContentMode); err != nil {
+		add("$.options.contentMode", "%v", err)
+	}
+	if opts.ScaffoldDate != "" {
+		if _, err := time.Parse("2006-01-02", opts.ScaffoldDate); err != nil {
+			add("$.options.scaffoldDate", "invalid date %q, expected YYYY-MM-DD", opts.ScaffoldDate)
+		}
+	}
+	return errs
+}
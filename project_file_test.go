@@ -0,0 +1,132 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProjectRoundTripKeepsGenerationOptions(t *testing.T) {
+	doc := &ProjectDocument{
+		SchemaVersion: projectSchemaVersion,
+		Year:          2024,
+		RepoName:      "art",
+		Contributions: []ContributionDay{{Date: "2024-01-02", Count: 3}, {Date: "2024-01-01", Count: 1}},
+	}
+	doc.Options.Language = "go"
+	doc.Options.Signing = &SigningOptions{Format: signingFormatSSH, Key: "~/.ssh/id_ed25519.pub"}
+	doc.Options.Authors = []WeightedIdentity{{Name: "Ada", Email: "ada@example.com", Weight: 2}}
+	doc.Options.Committer = &GitIdentity{Name: "Bot", Email: "bot@example.com"}
+	doc.Options.CommitMessage = &CommitMessageOptions{Template: "update {{.Date}}", ConventionalTypes: []string{"chore"}}
+	doc.Options.FileLayout = "daily"
+	doc.Options.ContentMode = "append"
+	doc.Options.Seed = 42
+
+	data, err := encodeProject(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, migrated, err := decodeProject(data)
+	if err != nil {
+		t.Fatalf("decodeProject: %v\n%s", err, data)
+	}
+	if migrated {
+		t.Error("current version should not be migrated")
+	}
+	if got.Contributions[0].Date != "2024-01-01" {
+		t.Errorf("contributions not sorted: %v", got.Contributions)
+	}
+	opts := got.Options
+	if opts.Signing == nil || opts.Signing.Format != signingFormatSSH || opts.Committer == nil || opts.Committer.Email != "bot@example.com" ||
+		len(opts.Authors) != 1 || opts.Authors[0].Weight != 2 || opts.CommitMessage == nil || opts.CommitMessage.Template != "update {{.Date}}" ||
+		opts.FileLayout != "daily" || opts.ContentMode != "append" || opts.Seed != 42 {
+		t.Errorf("options lost in round trip: %+v", opts)
+	}
+}
+
+// 版本 1 的项目文件原样加载，timezone 字段保留，不视为迁移。
+func TestDecodeProjectKeepsTimezone(t *testing.T) {
+	data := `{"schemaVersion": 1, "year": 2024, "repoName": "art", "timezone": "Europe/Berlin",
+		"contributions": [{"date": "2024-01-01", "count": 2}], "options": {"language": "go"}}`
+	doc, migrated, err := decodeProject([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if migrated || doc.SchemaVersion != 1 || doc.Timezone != "Europe/Berlin" {
+		t.Errorf("migrated = %v, version = %d, timezone = %q", migrated, doc.SchemaVersion, doc.Timezone)
+	}
+}
+
+func TestDecodeProjectMigratesBareContributions(t *testing.T) {
+	doc, migrated, err := decodeProject([]byte(`[{"date": "2023-05-01", "count": 4}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !migrated || doc.Year != 2023 || len(doc.Contributions) != 1 {
+		t.Errorf("migrated = %v, doc = %+v", migrated, doc)
+	}
+}
+
+func TestDecodeProjectErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		wantPath string
+	}{
+		{
+			name:     "unknown field",
+			data:     `{"schemaVersion": 1, "year": 2024, "colour": "green"}`,
+			wantPath: "$.colour",
+		},
+		{
+			name:     "unknown timezone",
+			data:     `{"schemaVersion": 1, "year": 2024, "timezone": "Mars/Olympus"}`,
+			wantPath: "$.timezone",
+		},
+		{
+			name:     "unknown option field",
+			data:     `{"schemaVersion": 1, "year": 2024, "options": {"colour": "green"}}`,
+			wantPath: "$.options.colour",
+		},
+		{
+			name:     "seed type",
+			data:     `{"schemaVersion": 1, "year": 2024, "options": {"seed": "42"}}`,
+			wantPath: "$.options.seed",
+		},
+		{
+			name:     "nested pointer type",
+			data:     `{"schemaVersion": 1, "year": 2024, "options": {"committer": {"name": 1}}}`,
+			wantPath: "$.options.committer.name",
+		},
+		{
+			name:     "bad layout",
+			data:     `{"schemaVersion": 1, "year": 2024, "options": {"fileLayout": "weekly"}}`,
+			wantPath: "$.options.fileLayout",
+		},
+		{
+			name:     "bad template",
+			data:     `{"schemaVersion": 1, "year": 2024, "options": {"commitMessage": {"template": "{{.Nope}}"}}}`,
+			wantPath: "$.options.commitMessage",
+		},
+		{
+			name:     "date outside year",
+			data:     `{"schemaVersion": 1, "year": 2024, "contributions": [{"date": "2023-01-01", "count": 1}]}`,
+			wantPath: "$.contributions[0].date",
+		},
+	}
+	for _, tt := range tests {
+		_, _, err := decodeProject([]byte(tt.data))
+		if err == nil {
+			t.Errorf("%s: expected error", tt.name)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.wantPath+":") {
+			t.Errorf("%s: error %q does not mention %s", tt.name, err, tt.wantPath)
+		}
+	}
+}
+
+func TestDecodeProjectRejectsNewerVersion(t *testing.T) {
+	if _, _, err := decodeProject([]byte(`{"schemaVersion": 99}`)); err == nil {
+		t.Error("expected error for a newer schema version")
+	}
+}
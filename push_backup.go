@@ -0,0 +1,184 @@
+// push_backup.go 为强制推送提供安全保障：推送前备份远程分支（远程备份 ref 与本地 bundle），
+// 使用 --force-with-lease 避免覆盖他人的新提交，并支持撤销最近一次强制推送。
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// pushBackupRefPrefix 是备份 ref 的命名空间，不属于 refs/heads，因此不会出现在分支列表中。
+const pushBackupRefPrefix = "refs/greenwall-backup/"
+
+// PushBackup 记录一次强制推送前远程分支的状态。
+type PushBackup struct {
+	RepoName     string `json:"repoName"`     // 远程仓库 (owner/repo)
+	Branch       string `json:"branch"`       // 被覆盖的分支
+	PreviousSHA  string `json:"previousSha"`  // 推送前远程分支指向的提交，为空表示分支原本不存在
+	PushedSHA    string `json:"pushedSha"`    // 本次推送后远程分支指向的提交
+	BackupRef    string `json:"backupRef"`    // 备份 ref 名称
+	RemoteBackup bool   `json:"remoteBackup"` // 备份 ref 是否已推送到远程仓库
+	BundlePath   string `json:"bundlePath"`   // 本地 bundle 备份文件路径
+	CreatedAt    string `json:"createdAt"`    // 备份时间 (RFC3339)
+}
+
+// GetLastPushBackup 返回最近一次强制推送的备份信息，没有可撤销的推送时返回 nil。
+func (a *App) GetLastPushBackup() (*PushBackup, error) {
+	backup, err := a.loadLastPushBackup()
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return backup, err
+}
+
+// UndoLastPush 将最近一次强制推送覆盖的远程分支恢复到推送前的状态。
+// 恢复同样使用 --force-with-lease，若远程分支在推送后又有新提交则拒绝恢复。
+func (a *App) UndoLastPush() (*PushRepoResponse, error) {
+	if a.userInfo == nil || a.userInfo.Token == "" {
+		return &PushRepoResponse{Success: false, Message: "未登录"}, nil
+	}
+	backup, err := a.loadLastPushBackup()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &PushRepoResponse{Success: false, Message: "没有可撤销的强制推送"}, nil
+		}
+		return nil, err
+	}
+	LogInfo("撤销强制推送",
+		zap.String("repo", backup.RepoName),
+		zap.String("branch", backup.Branch),
+		zap.String("previous_sha", backup.PreviousSHA))
+
+	tempDir, err := os.MkdirTemp("", "green-wall-undo-")
+	if err != nil {
+		return nil, fmt.Errorf("create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	if err := a.runGitCommand(tempDir, "init", "--bare"); err != nil {
+		return nil, err
+	}
+	if err := a.runGitCommand(tempDir, "remote", "add", "origin", a.githubRemoteURL(backup.RepoName)); err != nil {
+		return nil, err
+	}
+
+	lease := fmt.Sprintf("--force-with-lease=refs/heads/%s:%s", backup.Branch, backup.PushedSHA)
+	var pushErr error
+	if backup.PreviousSHA == "" {
+		// 推送前分支不存在，撤销即删除该分支
+		_, pushErr = a.runGitRemote(tempDir, "push", lease, "origin", ":refs/heads/"+backup.Branch)
+	} else {
+		if err := a.runGitCommand(tempDir, "fetch", backup.BundlePath, backup.BackupRef+":"+backup.BackupRef); err != nil {
+			LogError("读取备份失败", zap.String("bundle", backup.BundlePath), zap.Error(err))
+			return &PushRepoResponse{Success: false, Message: fmt.Sprintf("读取本地备份失败: %v", err)}, nil
+		}
+		_, pushErr = a.runGitRemote(tempDir, "push", lease, "origin", fmt.Sprintf("%s:refs/heads/%s", backup.PreviousSHA, backup.Branch))
+	}
+	if pushErr != nil {
+		LogError("撤销强制推送失败", zap.Error(pushErr))
+		return &PushRepoResponse{Success: false, Message: "撤销失败：远程分支在推送后已有新的变化，或分支受保护"}, nil
+	}
+
+	if err := os.Remove(a.lastPushBackupPath()); err != nil {
+		LogWarn("删除推送备份记录失败", zap.Error(err))
+	}
+	LogInfo("撤销强制推送成功", zap.String("repo", backup.RepoName), zap.String("branch", backup.Branch))
+	return &PushRepoResponse{
+		Success: true,
+		Message: fmt.Sprintf("已将 %s 的 %s 分支恢复到推送前的状态", backup.RepoName, backup.Branch),
+		RepoURL: fmt.Sprintf("https://github.com/%s", backup.RepoName),
+	}, nil
+}
+
+// backupRemoteBranch 记录远程分支当前指向的提交，并将其备份为本地 bundle 与远程备份 ref。
+// 返回的 PreviousSHA 用作 --force-with-lease 的期望值。
+func (a *App) backupRemoteBranch(repoPath, repoName, branch string) (*PushBackup, error) {
+	backup := &PushBackup{
+		RepoName:  repoName,
+		Branch:    branch,
+		CreatedAt: time.Now().Format(time.RFC3339),
+	}
+	listing, err := a.runGitRemote(repoPath, "ls-remote", "origin", "refs/heads/"+branch)
+	if err != nil {
+		return nil, err
+	}
+	if fields := strings.Fields(listing); len(fields) > 0 {
+		backup.PreviousSHA = fields[0]
+	}
+	if backup.PreviousSHA == "" {
+		LogInfo("远程分支不存在，无需备份", zap.String("branch", branch))
+		return backup, nil
+	}
+
+	backup.BackupRef = pushBackupRefPrefix + time.Now().UTC().Format("20060102T150405Z")
+	if _, err := a.runGitRemote(repoPath, "fetch", "origin", fmt.Sprintf("+refs/heads/%s:%s", branch, backup.BackupRef)); err != nil {
+		return nil, err
+	}
+	// 以实际取回的提交为准，保证备份与 lease 一致
+	if backup.PreviousSHA, err = a.runGitOutput(repoPath, "rev-parse", backup.BackupRef); err != nil {
+		return nil, err
+	}
+
+	backupDir := filepath.Join(filepath.Dir(a.getUserInfoPath()), "backups")
+	if err := os.MkdirAll(backupDir, 0o755); err != nil {
+		return nil, fmt.Errorf("create backup directory: %w", err)
+	}
+	bundleName := fmt.Sprintf("%s-%s-%s.bundle",
+		sanitiseRepoName(strings.ReplaceAll(repoName, "/", "-")),
+		sanitiseRepoName(branch),
+		strings.TrimPrefix(backup.BackupRef, pushBackupRefPrefix))
+	backup.BundlePath = filepath.Join(backupDir, bundleName)
+	if err := a.runGitCommand(repoPath, "bundle", "create", backup.BundlePath, backup.BackupRef); err != nil {
+		return nil, err
+	}
+
+	if _, err := a.runGitRemote(repoPath, "push", "origin", backup.BackupRef+":"+backup.BackupRef); err != nil {
+		LogWarn("推送远程备份 ref 失败，仅保留本地 bundle", zap.Error(err))
+	} else {
+		backup.RemoteBackup = true
+	}
+
+	LogInfo("已备份远程分支",
+		zap.String("branch", branch),
+		zap.String("sha", backup.PreviousSHA),
+		zap.String("bundle", backup.BundlePath),
+		zap.Bool("remote_backup", backup.RemoteBackup))
+	return backup, nil
+}
+
+func (a *App) lastPushBackupPath() string {
+	return filepath.Join(filepath.Dir(a.getUserInfoPath()), "backups", "last_push.json")
+}
+
+func (a *App) saveLastPushBackup(backup *PushBackup) error {
+	data, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal push backup: %w", err)
+	}
+	path := a.lastPushBackupPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create backup directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("write push backup: %w", err)
+	}
+	return nil
+}
+
+func (a *App) loadLastPushBackup() (*PushBackup, error) {
+	data, err := os.ReadFile(a.lastPushBackupPath())
+	if err != nil {
+		return nil, err
+	}
+	var backup PushBackup
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return nil, fmt.Errorf("parse push backup: %w", err)
+	}
+	return &backup, nil
+}
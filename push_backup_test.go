@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+// forcePushWithBackup 按 PushToGitHub 的强制推送流程备份远程分支，并以备份时的提交作为 lease 推送本地 main。
+func forcePushWithBackup(t *testing.T, a *App, repoPath, repoName, branch string) (*PushBackup, error) {
+	t.Helper()
+	backup, err := a.backupRemoteBranch(repoPath, repoName, branch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lease := fmt.Sprintf("--force-with-lease=refs/heads/%s:%s", branch, backup.PreviousSHA)
+	if _, err := a.runGitRemote(repoPath, "push", lease, "origin", "main:"+branch); err != nil {
+		return backup, err
+	}
+	if backup.PushedSHA, err = a.runGitOutput(repoPath, "rev-parse", "main"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.saveLastPushBackup(backup); err != nil {
+		t.Fatal(err)
+	}
+	return backup, nil
+}
+
+// newPushFixture 创建远程仓库 octo/wall（main 分支含一个提交）与一个历史无关的本地仓库，返回裸仓库、本地仓库、
+// 用于模拟他人推送的仓库以及远程 main 原本的提交。
+func newPushFixture(t *testing.T, a *App) (bare, repoPath, other, original string) {
+	t.Helper()
+	a.userInfo = &UserInfo{Username: "octo", Token: "gho_secret"}
+	bare = newBareRemote(t, a, "octo", "wall")
+
+	other = t.TempDir()
+	if err := a.runGitCommand(other, "init", "-q", "-b", "main"); err != nil {
+		t.Fatal(err)
+	}
+	original = commitFile(t, a, other, "notes.txt", "real work\n")
+	if err := a.runGitCommand(other, "push", "-q", bare, "main"); err != nil {
+		t.Fatal(err)
+	}
+
+	repoPath = t.TempDir()
+	if err := a.runGitCommand(repoPath, "init", "-q", "-b", "main"); err != nil {
+		t.Fatal(err)
+	}
+	commitFile(t, a, repoPath, "activity.md", "generated\n")
+	if err := a.runGitCommand(repoPath, "remote", "add", "origin", a.githubRemoteURL("wall")); err != nil {
+		t.Fatal(err)
+	}
+	return bare, repoPath, other, original
+}
+
+func TestUndoLastPush(t *testing.T) {
+	a := newTestApp(t)
+	bare, repoPath, _, original := newPushFixture(t, a)
+
+	backup, err := forcePushWithBackup(t, a, repoPath, "octo/wall", "main")
+	if err != nil {
+		t.Fatalf("force push: %v", err)
+	}
+	if backup.PreviousSHA != original || !backup.RemoteBackup {
+		t.Fatalf("backup = %+v, want previous %s with a remote backup ref", backup, original)
+	}
+	if sha, err := a.runGitOutput(bare, "rev-parse", backup.BackupRef); err != nil || sha != original {
+		t.Errorf("remote backup ref = %q, %v", sha, err)
+	}
+	if err := a.runGitCommand(repoPath, "bundle", "verify", backup.BundlePath); err != nil {
+		t.Errorf("bundle: %v", err)
+	}
+	if sha, _ := a.runGitOutput(bare, "rev-parse", "main"); sha != backup.PushedSHA {
+		t.Fatalf("remote main = %s after force push, want %s", sha, backup.PushedSHA)
+	}
+
+	// 撤销时取回的是本地 bundle，不依赖远程备份 ref
+	if err := a.runGitCommand(bare, "update-ref", "-d", backup.BackupRef); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := a.UndoLastPush()
+	if err != nil || !resp.Success {
+		t.Fatalf("undo = %+v, %v", resp, err)
+	}
+	if sha, _ := a.runGitOutput(bare, "rev-parse", "main"); sha != original {
+		t.Fatalf("remote main = %s after undo, want %s", sha, original)
+	}
+	if _, err := os.Stat(a.lastPushBackupPath()); !os.IsNotExist(err) {
+		t.Errorf("backup record still exists after undo: %v", err)
+	}
+	if resp, err := a.UndoLastPush(); err != nil || resp.Success {
+		t.Errorf("second undo = %+v, %v; want nothing to undo", resp, err)
+	}
+}
+
+// 推送前分支不存在时，撤销即删除该分支。
+func TestUndoLastPushNewBranch(t *testing.T) {
+	a := newTestApp(t)
+	bare, repoPath, _, _ := newPushFixture(t, a)
+
+	backup, err := forcePushWithBackup(t, a, repoPath, "octo/wall", "feature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if backup.PreviousSHA != "" || backup.BundlePath != "" {
+		t.Fatalf("backup of a missing branch = %+v", backup)
+	}
+	if resp, err := a.UndoLastPush(); err != nil || !resp.Success {
+		t.Fatalf("undo = %+v, %v", resp, err)
+	}
+	if err := a.runGitCommand(bare, "rev-parse", "--verify", "-q", "refs/heads/feature"); err == nil {
+		t.Error("feature branch still exists after undo")
+	}
+}
+
+// 远程分支在备份与推送之间、或推送与撤销之间被他人更新时，lease 拒绝覆盖。
+func TestPushLeaseRejectsRemoteChanges(t *testing.T) {
+	a := newTestApp(t)
+	bare, repoPath, other, _ := newPushFixture(t, a)
+
+	backup, err := a.backupRemoteBranch(repoPath, "octo/wall", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	moved := commitFile(t, a, other, "more.txt", "more work\n")
+	if err := a.runGitCommand(other, "push", "-q", bare, "main"); err != nil {
+		t.Fatal(err)
+	}
+	lease := fmt.Sprintf("--force-with-lease=refs/heads/main:%s", backup.PreviousSHA)
+	if _, err := a.runGitRemote(repoPath, "push", lease, "origin", "main:main"); err == nil {
+		t.Fatal("force push succeeded although the remote moved after the backup")
+	}
+	if sha, _ := a.runGitOutput(bare, "rev-parse", "main"); sha != moved {
+		t.Fatalf("remote main = %s, want the other push %s", sha, moved)
+	}
+
+	// 推送之后远程又有新提交，撤销同样被拒绝，远程保持不变
+	if _, err := forcePushWithBackup(t, a, repoPath, "octo/wall", "main"); err != nil {
+		t.Fatal(err)
+	}
+	later := commitFile(t, a, other, "later.txt", "later work\n")
+	if err := a.runGitCommand(other, "push", "-q", "--force", bare, "main"); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := a.UndoLastPush()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Success || !strings.Contains(resp.Message, "撤销失败") {
+		t.Fatalf("undo = %+v, want lease failure", resp)
+	}
+	if sha, _ := a.runGitOutput(bare, "rev-parse", "main"); sha != later {
+		t.Fatalf("remote main = %s after rejected undo, want %s", sha, later)
+	}
+}
@@ -0,0 +1,187 @@
+// shading.go 模拟 GitHub 贡献图的色阶规则，并据此反推达到目标色阶所需的提交数。
+package main
+
+import (
+	"sort"
+)
+
+// maxContributionLevel 是贡献图的最高色阶（0 表示空白格子）。
+const maxContributionLevel = 4
+
+// 格子无法呈现目标色阶的原因。
+const (
+	shadingReasonExistingTooHigh = "existing_too_high" // 已有贡献无法撤销，格子无法变浅或留空
+	shadingReasonDistribution    = "distribution"      // 各色阶格子数量的分布无法满足分位数规则
+)
+
+// ShadingWarning 描述一个按 GitHub 着色规则无法呈现目标色阶的格子。
+type ShadingWarning struct {
+	Date        string `json:"date"`        // 日期 YYYY-MM-DD
+	TargetLevel int    `json:"targetLevel"` // 期望色阶
+	ActualLevel int    `json:"actualLevel"` // 预计实际呈现的色阶
+	Existing    int    `json:"existing"`    // 该日已有的贡献数
+	Reason      string `json:"reason"`      // 原因代码
+}
+
+// 前端画笔的色阶与提交数对应关系，需与 frontend/src/constants.ts 中的 CONTRIBUTION_LEVELS 保持一致。
+const (
+	drawingLevel2Count = 5
+	drawingLevel3Count = 10
+	drawingLevel4Count = 20
+)
+
+// levelFromDrawingCount 将前端画布上的提交数还原为用户想要的色阶 (0-4)。
+func levelFromDrawingCount(count int) int {
+	switch {
+	case count <= 0:
+		return 0
+	case count < drawingLevel2Count:
+		return 1
+	case count < drawingLevel3Count:
+		return 2
+	case count < drawingLevel4Count:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// drawingCountForLevel 返回前端画笔在某个色阶下使用的提交数，是 levelFromDrawingCount 的逆运算。
+func drawingCountForLevel(level int) int {
+	switch {
+	case level <= 0:
+		return 0
+	case level == 1:
+		return 1
+	case level == 2:
+		return drawingLevel2Count
+	case level == 3:
+		return drawingLevel3Count
+	default:
+		return drawingLevel4Count
+	}
+}
+
+// quantileThresholds 计算一组非零贡献数的 25%/50%/75% 分位数，作为色阶分界。
+// 采用与 d3.scaleQuantile 相同的线性插值算法（R-7），GitHub 的贡献图即基于该规则着色。
+func quantileThresholds(values []int) []float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	sorted := make([]int, len(values))
+	copy(sorted, values)
+	sort.Ints(sorted)
+
+	thresholds := make([]float64, 0, maxContributionLevel-1)
+	for i := 1; i < maxContributionLevel; i++ {
+		h := float64(len(sorted)-1) * float64(i) / maxContributionLevel
+		lo := int(h)
+		t := float64(sorted[lo])
+		if lo+1 < len(sorted) {
+			t += (h - float64(lo)) * float64(sorted[lo+1]-sorted[lo])
+		}
+		thresholds = append(thresholds, t)
+	}
+	return thresholds
+}
+
+// levelForCount 根据分位数分界计算单个格子的色阶，恰好落在分界上的值归入更深的一级。
+func levelForCount(count int, thresholds []float64) int {
+	if count <= 0 {
+		return 0
+	}
+	level := 1
+	for _, t := range thresholds {
+		if float64(count) >= t {
+			level++
+		}
+	}
+	return level
+}
+
+// githubLevels 按 GitHub 的分位数规则计算每一天在贡献图上呈现的色阶。
+// counts 应包含贡献图所展示范围（通常为一整年）内的全部数据。
+func githubLevels(counts map[string]int) map[string]int {
+	var nonZero []int
+	for _, c := range counts {
+		if c > 0 {
+			nonZero = append(nonZero, c)
+		}
+	}
+	thresholds := quantileThresholds(nonZero)
+
+	levels := make(map[string]int, len(counts))
+	for date, c := range counts {
+		levels[date] = levelForCount(c, thresholds)
+	}
+	return levels
+}
+
+// solveShadingCounts 在已有贡献 (baseline) 的基础上，为每一天计算最终需要达到的贡献数，
+// 使 GitHub 渲染出的色阶尽量与 target 一致。提交只能增加不能撤销，因此结果不会低于 baseline。
+//
+// 分位数着色要求较浅色阶的所有格子都严格小于较深色阶的所有格子，
+// 所以逐级抬高下界即可得到每一天所需的最小值；是否真的能着色成功取决于各色阶的格子数量分布，
+// 由调用方通过 githubLevels 复核。
+func solveShadingCounts(dates []string, target map[string]int, baseline map[string]int) map[string]int {
+	final := make(map[string]int, len(dates))
+	byLevel := make([][]string, maxContributionLevel+1)
+	for _, date := range dates {
+		level := target[date]
+		if level < 0 {
+			level = 0
+		}
+		if level > maxContributionLevel {
+			level = maxContributionLevel
+		}
+		byLevel[level] = append(byLevel[level], date)
+	}
+
+	// 色阶 0 无法通过新增提交实现，保持原样
+	for _, date := range byLevel[0] {
+		final[date] = baseline[date]
+	}
+
+	floor := 1
+	for level := 1; level <= maxContributionLevel; level++ {
+		highest := 0
+		for _, date := range byLevel[level] {
+			c := baseline[date]
+			if c < floor {
+				c = floor
+			}
+			final[date] = c
+			if c > highest {
+				highest = c
+			}
+		}
+		if highest > 0 {
+			floor = highest + 1
+		}
+	}
+	return final
+}
+
+// shadingWarnings 用 GitHub 的着色规则复核最终贡献数，列出无法呈现目标色阶的格子。
+func shadingWarnings(dates []string, target map[string]int, baseline map[string]int, final map[string]int) []ShadingWarning {
+	levels := githubLevels(final)
+
+	var warnings []ShadingWarning
+	for _, date := range dates {
+		if levels[date] == target[date] {
+			continue
+		}
+		reason := shadingReasonDistribution
+		if target[date] < levels[date] && baseline[date] == final[date] && baseline[date] > 0 {
+			reason = shadingReasonExistingTooHigh
+		}
+		warnings = append(warnings, ShadingWarning{
+			Date:        date,
+			TargetLevel: target[date],
+			ActualLevel: levels[date],
+			Existing:    baseline[date],
+			Reason:      reason,
+		})
+	}
+	return warnings
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestGenerateRepoSSHSigned(t *testing.T) {
+	a := newTestApp(t)
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen is not installed")
+	}
+	keyDir := t.TempDir()
+	key := filepath.Join(keyDir, "id_ed25519")
+	if out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", "tester@example.com", "-f", key).CombinedOutput(); err != nil {
+		t.Fatalf("ssh-keygen: %v: %s", err, out)
+	}
+	publicKey, err := os.ReadFile(key + ".pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	allowedSigners := filepath.Join(keyDir, "allowed_signers")
+	if err := os.WriteFile(allowedSigners, []byte("tester@example.com "+string(publicKey)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	req := GenerateRepoRequest{
+		Year:           2024,
+		GithubUsername: "tester",
+		GithubEmail:    "tester@example.com",
+		RepoName:       "signed",
+		Contributions: []ContributionDay{
+			{Date: "2024-01-02", Count: 2},
+			{Date: "2024-03-04", Count: 1},
+		},
+	}
+	req.Signing = &SigningOptions{Format: signingFormatSSH, Key: key}
+	req.Seed = 3
+	resp, err := a.GenerateRepo(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revList, err := a.runGitOutput(resp.RepoPath, "rev-list", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	revs := strings.Fields(revList)
+	if len(revs) != resp.CommitCount {
+		t.Fatalf("main has %d commits, response reports %d", len(revs), resp.CommitCount)
+	}
+	for _, rev := range revs {
+		if err := a.runGitCommand(resp.RepoPath, "-c", "gpg.ssh.allowedSignersFile="+allowedSigners, "verify-commit", rev); err != nil {
+			t.Errorf("commit %s is not signed: %v", rev, err)
+		}
+	}
+
+	// 签名只重建提交对象，树与作者时间保持不变
+	dates, err := a.runGitOutput(resp.RepoPath, "log", "--format=%ad", "--date=short", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(dates, "2024-01-02") || !strings.Contains(dates, "2024-03-04") {
+		t.Errorf("author dates = %q", dates)
+	}
+}
+
+// 使用临时 GNUPGHOME 中新生成的 GPG 密钥签名，每个提交都能通过 git verify-commit 校验。
+func TestGenerateRepoGPGSigned(t *testing.T) {
+	a := newTestApp(t)
+	if _, err := exec.LookPath("gpg"); err != nil {
+		t.Skip("gpg is not installed")
+	}
+	gnupgHome := t.TempDir()
+	t.Setenv("GNUPGHOME", gnupgHome)
+	// 临时目录删除之前先停止 gpg 启动的 agent
+	t.Cleanup(func() { exec.Command("gpgconf", "--kill", "gpg-agent").Run() })
+	if out, err := exec.Command("gpg", "--batch", "--passphrase", "", "--quick-gen-key",
+		"tester <tester@example.com>", "ed25519", "sign", "never").CombinedOutput(); err != nil {
+		t.Fatalf("gpg --quick-gen-key: %v: %s", err, out)
+	}
+	listing, err := exec.Command("gpg", "--batch", "--with-colons", "--list-secret-keys").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fingerprint string
+	for _, line := range strings.Split(string(listing), "\n") {
+		if fields := strings.Split(line, ":"); fields[0] == "fpr" && len(fields) > 9 {
+			fingerprint = fields[9]
+			break
+		}
+	}
+	if fingerprint == "" {
+		t.Fatalf("no fingerprint in key listing:\n%s", listing)
+	}
+
+	req := GenerateRepoRequest{
+		Year:           2024,
+		GithubUsername: "tester",
+		GithubEmail:    "tester@example.com",
+		RepoName:       "gpg-signed",
+		Contributions: []ContributionDay{
+			{Date: "2024-01-02", Count: 2},
+			{Date: "2024-03-04", Count: 1},
+		},
+	}
+	req.Signing = &SigningOptions{Format: signingFormatOpenPGP, Key: fingerprint}
+	resp, err := a.GenerateRepo(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := a.runGitOutput(resp.RepoPath, "log", "--format=%H %G? %GF", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(out, "\n")
+	if len(lines) != resp.CommitCount {
+		t.Fatalf("main has %d commits, response reports %d", len(lines), resp.CommitCount)
+	}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) != 3 || fields[1] != "G" || fields[2] != fingerprint {
+			t.Errorf("commit %q is not signed with %s", line, fingerprint)
+			continue
+		}
+		if err := a.runGitCommand(resp.RepoPath, "verify-commit", fields[0]); err != nil {
+			t.Errorf("verify-commit %s: %v", fields[0], err)
+		}
+	}
+}
+
+func TestGenerateRepoSSHSigningRequiresKey(t *testing.T) {
+	a := newTestApp(t)
+	req := GenerateRepoRequest{
+		Year:           2024,
+		GithubUsername: "tester",
+		GithubEmail:    "tester@example.com",
+		RepoName:       "unsigned",
+		Contributions:  []ContributionDay{{Date: "2024-01-02", Count: 1}},
+	}
+	req.Signing = &SigningOptions{Format: signingFormatSSH}
+	if _, err := a.GenerateRepo(req); err == nil || !strings.Contains(err.Error(), "signing key") {
+		t.Fatalf("error = %v, want missing signing key", err)
+	}
+}
+
+func TestParseCommitBatch(t *testing.T) {
+	signed := "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"parent 1111111111111111111111111111111111111111\n" +
+		"author Jane Doe <jane@example.com> 1704153600 +0800\n" +
+		"committer GitHub <noreply@github.com> 1704157200 +0000\n" +
+		"gpgsig -----BEGIN SSH SIGNATURE-----\n" +
+		" U1NIU0lH\n" +
+		" -----END SSH SIGNATURE-----\n" +
+		"\n" +
+		"Add feature\n\nCo-authored-by: Bob <bob@example.com>\n"
+	root := "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"author A <a@example.com> 1704067200 +0000\n" +
+		"committer A <a@example.com> 1704067200 +0000\n" +
+		"\n" +
+		"Initial commit\n"
+	batch := "2222222222222222222222222222222222222222 commit " + strconv.Itoa(len(signed)) + "\n" + signed + "\n" +
+		"1111111111111111111111111111111111111111 commit " + strconv.Itoa(len(root)) + "\n" + root + "\n"
+
+	commits, err := parseCommitBatch(batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("parsed %d commits, want 2", len(commits))
+	}
+	c := commits["2222222222222222222222222222222222222222"]
+	if c.tree != "4b825dc642cb6eb9a060e54bf8d69288fbee4904" {
+		t.Errorf("tree = %q", c.tree)
+	}
+	if len(c.parents) != 1 || c.parents[0] != "1111111111111111111111111111111111111111" {
+		t.Errorf("parents = %v", c.parents)
+	}
+	if c.author != (commitIdent{name: "Jane Doe", email: "jane@example.com", date: "1704153600 +0800"}) {
+		t.Errorf("author = %+v", c.author)
+	}
+	if c.committer != (commitIdent{name: "GitHub", email: "noreply@github.com", date: "1704157200 +0000"}) {
+		t.Errorf("committer = %+v", c.committer)
+	}
+	// 原有签名被丢弃，说明（含尾部）原样保留
+	if c.message != "Add feature\n\nCo-authored-by: Bob <bob@example.com>\n" {
+		t.Errorf("message = %q", c.message)
+	}
+	if r := commits["1111111111111111111111111111111111111111"]; len(r.parents) != 0 || r.message != "Initial commit\n" {
+		t.Errorf("root commit = %+v", r)
+	}
+
+	if commits, err := parseCommitBatch(""); err != nil || len(commits) != 0 {
+		t.Errorf("empty batch = %v, %v", commits, err)
+	}
+}
+
+func TestParseCommitBatchErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		batch string
+	}{
+		{name: "not a commit", batch: "abc blob 3\nabc\n"},
+		{name: "missing object", batch: "abc missing\n"},
+		{name: "bad size", batch: "abc commit x\n"},
+		{name: "truncated body", batch: "abc commit 100\ntree 1\n"},
+		{name: "missing tree", batch: "abc commit 12\nparent 1\n\nm\n\n"},
+		{name: "malformed identity", batch: "abc commit 21\ntree 1\nauthor nobody\n\n"},
+	}
+	for _, tt := range tests {
+		if _, err := parseCommitBatch(tt.batch); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
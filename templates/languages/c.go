@@ -87,3 +87,9 @@ program
 `,
 	}
 }
+
+func (t *CTemplate) GetFileHeader() string { return "/* Contribution records generated by GreenWall */\n" }
+
+func (t *CTemplate) GenerateFragment(date string, commitNum int, totalCommits int) string {
+	return fmt.Sprintf("/* Contribution on %s (%d/%d) */\nconst char *contribution_%s(void)\n{\n    return \"%s %d/%d\";\n}\n", date, commitNum, totalCommits, fragmentID(date, commitNum), date, commitNum, totalCommits)
+}
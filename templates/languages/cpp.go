@@ -82,3 +82,9 @@ cmake-build-*/
 `,
 	}
 }
+
+func (t *CppTemplate) GetFileHeader() string { return "// Contribution records generated by GreenWall\n#include <string>\n" }
+
+func (t *CppTemplate) GenerateFragment(date string, commitNum int, totalCommits int) string {
+	return fmt.Sprintf("// Contribution on %s (%d/%d)\nstd::string contribution_%s() {\n    return \"%s %d/%d\";\n}\n", date, commitNum, totalCommits, fragmentID(date, commitNum), date, commitNum, totalCommits)
+}
@@ -72,3 +72,9 @@ obj/
 `,
 	}
 }
+
+func (t *CSharpTemplate) GetFileHeader() string { return "// Contribution records generated by GreenWall\nnamespace GreenWall.Contributions;\n" }
+
+func (t *CSharpTemplate) GenerateFragment(date string, commitNum int, totalCommits int) string {
+	return fmt.Sprintf("public static class Contribution%s\n{\n    public const string Date = \"%s\";\n    public const int Commit = %d;\n    public const int Total = %d;\n}\n", fragmentID(date, commitNum), date, commitNum, totalCommits)
+}
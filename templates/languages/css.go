@@ -49,3 +49,9 @@ func (t *CSSTemplate) GetAdditionalFiles(repoName string) map[string]string {
 `,
 	}
 }
+
+func (t *CSSTemplate) GetFileHeader() string { return "/* Contribution records generated by GreenWall */\n" }
+
+func (t *CSSTemplate) GenerateFragment(date string, commitNum int, totalCommits int) string {
+	return fmt.Sprintf(".contribution-%s {\n  --date: \"%s\";\n  --commit: %d;\n  --total: %d;\n}\n", fragmentID(date, commitNum), date, commitNum, totalCommits)
+}
@@ -99,11 +99,9 @@ func GetSupportedLanguages() []map[string]string {
 }
 
 // GetCodeFilePath 根据基础路径和模板信息计算源码文件的绝对存储路径。
+// 该函数对应 single 布局，按布局计算路径请使用 GetLayoutFilePath。
 func GetCodeFilePath(basePath string, lang LanguageType, date string, commitNum int) string {
-	template := GetLanguageTemplate(lang)
-	filename := template.GetActivityFileName()
-	
-	// 目前策略：每个语言的所有提交共享同一个活动文件，Git 会记录增量变更。
+	filename := GetLayoutFilePath(lang, LayoutSingle, date)
 	
 	if basePath == "" {
 		return filename
@@ -0,0 +1,58 @@
+package languages
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// ContentMode 决定每个提交写入活动文件的内容。
+type ContentMode string
+
+const (
+	ContentFull   ContentMode = "full"   // 每次提交重新渲染完整的模板内容（默认）
+	ContentAppend ContentMode = "append" // 每次提交在文件末尾追加一段代码片段
+)
+
+// ParseContentMode 解析内容模式名称，空字符串表示默认的 full。
+func ParseContentMode(value string) (ContentMode, error) {
+	switch mode := ContentMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return ContentFull, nil
+	case ContentFull, ContentAppend:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unsupported content mode %q", value)
+	}
+}
+
+// GetFragmentTemplate 返回语言的增量模板，未实现增量模式的语言回退到 Markdown。
+func GetFragmentTemplate(lang LanguageType) FragmentTemplate {
+	if t, ok := GetLanguageTemplate(lang).(FragmentTemplate); ok {
+		return t
+	}
+	return &MarkdownTemplate{}
+}
+
+// AppendFragment 将一个提交的代码片段原地追加到活动文件的当前内容 existing 之后并返回新内容，
+// existing 为空时先写入文件头。已有内容不会被复制，长历史下每次提交只增加片段本身的开销。
+func AppendFragment(existing []byte, lang LanguageType, date string, commitNum int, totalCommits int) []byte {
+	t := GetFragmentTemplate(lang)
+	if len(existing) == 0 {
+		existing = append(existing, t.GetFileHeader()...)
+	}
+	if len(existing) > 0 {
+		existing = append(bytes.TrimRight(existing, "\n"), "\n\n"...)
+	}
+	return append(existing, t.GenerateFragment(date, commitNum, totalCommits)...)
+}
+
+// GetFragmentBytes 返回一个提交在增量模式下平均新增的字节数，用于按字节比例分配语言。
+func GetFragmentBytes(lang LanguageType) int {
+	return len(GetFragmentTemplate(lang).GenerateFragment("2024-01-01", 1, 1)) + 1
+}
+
+// fragmentID 返回日期与序号组成的标识符后缀，如 20240115_2。
+func fragmentID(date string, commitNum int) string {
+	return fmt.Sprintf("%s_%d", strings.ReplaceAll(date, "-", ""), commitNum)
+}
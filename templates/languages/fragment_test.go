@@ -0,0 +1,32 @@
+package languages
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAppendFragment(t *testing.T) {
+	tmpl := GetFragmentTemplate(LangGo)
+	content := AppendFragment(nil, LangGo, "2024-01-02", 1, 2)
+	content = AppendFragment(content, LangGo, "2024-01-02", 2, 2)
+
+	want := strings.TrimRight(tmpl.GetFileHeader(), "\n") + "\n\n" +
+		strings.TrimRight(tmpl.GenerateFragment("2024-01-02", 1, 2), "\n") + "\n\n" +
+		tmpl.GenerateFragment("2024-01-02", 2, 2)
+	if string(content) != want {
+		t.Fatalf("content = %q, want %q", content, want)
+	}
+}
+
+// 追加在已有缓冲区上原地进行，容量足够时不会复制文件已有的内容。
+func TestAppendFragmentInPlace(t *testing.T) {
+	buf := make([]byte, 0, 1<<16)
+	content := AppendFragment(buf, LangPython, "2024-01-02", 1, 3)
+	for i := 2; i <= 3; i++ {
+		next := AppendFragment(content, LangPython, "2024-01-02", i, 3)
+		if &next[0] != &buf[:1][0] {
+			t.Fatalf("fragment %d reallocated the file content", i)
+		}
+		content = next
+	}
+}
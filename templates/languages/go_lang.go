@@ -81,3 +81,9 @@ go.work
 `,
 	}
 }
+
+func (t *GoTemplate) GetFileHeader() string { return "// Package contributions contains contribution records generated by GreenWall.\npackage contributions\n" }
+
+func (t *GoTemplate) GenerateFragment(date string, commitNum int, totalCommits int) string {
+	return fmt.Sprintf("// Contribution%s records the contribution on %s (%d/%d).\nfunc Contribution%s() (string, int, int) {\n\treturn \"%s\", %d, %d\n}\n", fragmentID(date, commitNum), date, commitNum, totalCommits, fragmentID(date, commitNum), date, commitNum, totalCommits)
+}
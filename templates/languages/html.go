@@ -55,3 +55,9 @@ func (t *HTMLTemplate) GetAdditionalFiles(repoName string) map[string]string {
 `,
 	}
 }
+
+func (t *HTMLTemplate) GetFileHeader() string { return "<!-- Contribution records generated by GreenWall -->\n" }
+
+func (t *HTMLTemplate) GenerateFragment(date string, commitNum int, totalCommits int) string {
+	return fmt.Sprintf("<section id=\"contribution-%s\">\n  <p>Contribution on %s (%d/%d)</p>\n</section>\n", fragmentID(date, commitNum), date, commitNum, totalCommits)
+}
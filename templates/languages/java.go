@@ -84,3 +84,9 @@ func (t *JavaTemplate) GetAdditionalFiles(repoName string) map[string]string {
 `,
 	}
 }
+
+func (t *JavaTemplate) GetFileHeader() string { return "// Contribution records generated by GreenWall\npackage com.greenwall.contributions;\n" }
+
+func (t *JavaTemplate) GenerateFragment(date string, commitNum int, totalCommits int) string {
+	return fmt.Sprintf("final class Contribution%s {\n    static final String DATE = \"%s\";\n    static final int COMMIT = %d;\n    static final int TOTAL = %d;\n}\n", fragmentID(date, commitNum), date, commitNum, totalCommits)
+}
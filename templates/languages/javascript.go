@@ -75,3 +75,9 @@ build/
 `,
 	}
 }
+
+func (t *JavaScriptTemplate) GetFileHeader() string { return "// Contribution records generated by GreenWall\n" }
+
+func (t *JavaScriptTemplate) GenerateFragment(date string, commitNum int, totalCommits int) string {
+	return fmt.Sprintf("export function contribution_%s() {\n  return { date: '%s', commit: %d, total: %d };\n}\n", fragmentID(date, commitNum), date, commitNum, totalCommits)
+}
@@ -63,3 +63,9 @@ dependencies {
 `,
 	}
 }
+
+func (t *KotlinTemplate) GetFileHeader() string { return "// Contribution records generated by GreenWall\npackage com.greenwall.contributions\n" }
+
+func (t *KotlinTemplate) GenerateFragment(date string, commitNum int, totalCommits int) string {
+	return fmt.Sprintf("fun contribution%s(): Triple<String, Int, Int> = Triple(\"%s\", %d, %d)\n", fragmentID(date, commitNum), date, commitNum, totalCommits)
+}
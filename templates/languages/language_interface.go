@@ -48,3 +48,13 @@ type LanguageTemplate interface {
 	// GetLanguageName 返回用于前端显示的友好语言名称（如 "Java", "Go"）。
 	GetLanguageName() string
 }
+
+// FragmentTemplate 由支持增量内容模式的模板实现：每个提交只生成一段追加到活动文件末尾的代码，
+// 使提交的 diff 只包含新增内容。
+type FragmentTemplate interface {
+	// GetFileHeader 返回新建活动文件时写在最前面的内容（如 package 声明），可以为空。
+	GetFileHeader() string
+
+	// GenerateFragment 生成一个提交追加的代码片段（一个函数、测试用例或日志条目）。
+	GenerateFragment(date string, commitNum int, totalCommits int) string
+}
@@ -0,0 +1,89 @@
+package languages
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FileLayout 决定每个提交的代码写入仓库中的哪个文件。
+type FileLayout string
+
+const (
+	LayoutSingle  FileLayout = "single"  // 每种语言一个活动文件，每次提交整体改写（默认）
+	LayoutDaily   FileLayout = "daily"   // 每天一个文件，如 contributions/2024/01/15.py
+	LayoutMonthly FileLayout = "monthly" // 每月一个文件，如 contributions/2024/01.py
+	LayoutAppend  FileLayout = "append"  // 每种语言一个活动文件，每次提交在末尾追加一段记录
+)
+
+// ParseFileLayout 解析文件布局名称，空字符串表示默认的 single。
+func ParseFileLayout(value string) (FileLayout, error) {
+	switch layout := FileLayout(strings.ToLower(strings.TrimSpace(value))); layout {
+	case "":
+		return LayoutSingle, nil
+	case LayoutSingle, LayoutDaily, LayoutMonthly, LayoutAppend:
+		return layout, nil
+	default:
+		return "", fmt.Errorf("unsupported file layout %q", value)
+	}
+}
+
+// Appends 表示写入同一文件的后续提交是否保留原有内容并在末尾追加。
+// 只有 single 布局会整体改写文件。
+func (l FileLayout) Appends() bool {
+	return l != LayoutSingle && l != ""
+}
+
+// GetLayoutFilePath 返回某语言在指定日期 (YYYY-MM-DD) 的提交应写入的相对路径，使用 "/" 分隔。
+func GetLayoutFilePath(lang LanguageType, layout FileLayout, date string) string {
+	template := GetLanguageTemplate(lang)
+	switch layout {
+	case LayoutDaily:
+		if len(date) == len("2006-01-02") {
+			return fmt.Sprintf("contributions/%s/%s/%s%s", date[:4], date[5:7], date[8:], template.GetFileExtension())
+		}
+	case LayoutMonthly:
+		if len(date) == len("2006-01-02") {
+			return fmt.Sprintf("contributions/%s/%s%s", date[:4], date[5:7], template.GetFileExtension())
+		}
+	}
+	return template.GetActivityFileName()
+}
+
+// LayoutStructureLine 返回 README “Structure” 一节中描述该语言活动文件的列表项。
+func LayoutStructureLine(lang LanguageType, layout FileLayout) string {
+	template := GetLanguageTemplate(lang)
+	ext := template.GetFileExtension()
+	name := template.GetLanguageName()
+	switch layout {
+	case LayoutDaily:
+		return fmt.Sprintf("- `contributions/YYYY/MM/DD%s` - %s contribution records, one file per day", ext, name)
+	case LayoutMonthly:
+		return fmt.Sprintf("- `contributions/YYYY/MM%s` - %s contribution records, one file per month", ext, name)
+	case LayoutAppend:
+		return fmt.Sprintf("- `%s` - %s contribution log, one entry appended per commit", template.GetActivityFileName(), name)
+	default:
+		return fmt.Sprintf("- `%s` - %s contribution record, updated by every commit", template.GetActivityFileName(), name)
+	}
+}
+
+// ApplyStructureLines 将活动文件的描述插入 README 的 “## Structure” 一节开头；
+// README 中没有该节时，在 “## License” 之前（或末尾）新增一节。
+func ApplyStructureLines(readme string, lines []string) string {
+	if len(lines) == 0 {
+		return readme
+	}
+	block := strings.Join(lines, "\n") + "\n"
+	const heading = "## Structure\n\n"
+	if i := strings.Index(readme, heading); i >= 0 {
+		at := i + len(heading)
+		return readme[:at] + block + readme[at:]
+	}
+	section := heading + block + "\n"
+	if i := strings.Index(readme, "## License"); i >= 0 {
+		return readme[:i] + section + readme[i:]
+	}
+	if !strings.HasSuffix(readme, "\n\n") {
+		readme = strings.TrimRight(readme, "\n") + "\n\n"
+	}
+	return readme + strings.TrimRight(section, "\n") + "\n"
+}
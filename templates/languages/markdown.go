@@ -22,3 +22,9 @@ func (t *MarkdownTemplate) GetReadmeContent(repoName string) string {
 func (t *MarkdownTemplate) GetAdditionalFiles(repoName string) map[string]string {
 	return map[string]string{}
 }
+
+func (t *MarkdownTemplate) GetFileHeader() string { return "" }
+
+func (t *MarkdownTemplate) GenerateFragment(date string, commitNum int, totalCommits int) string {
+	return fmt.Sprintf("- %s: commit %d of %d\n", date, commitNum, totalCommits)
+}
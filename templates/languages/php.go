@@ -70,3 +70,9 @@ composer.lock
 `,
 	}
 }
+
+func (t *PHPTemplate) GetFileHeader() string { return "<?php\n\n// Contribution records generated by GreenWall\n" }
+
+func (t *PHPTemplate) GenerateFragment(date string, commitNum int, totalCommits int) string {
+	return fmt.Sprintf("function contribution_%s(): array\n{\n    return ['date' => '%s', 'commit' => %d, 'total' => %d];\n}\n", fragmentID(date, commitNum), date, commitNum, totalCommits)
+}
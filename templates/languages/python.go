@@ -99,3 +99,9 @@ ENV/
 `,
 	}
 }
+
+func (t *PythonTemplate) GetFileHeader() string { return "\"\"\"Contribution records generated by GreenWall.\"\"\"\n" }
+
+func (t *PythonTemplate) GenerateFragment(date string, commitNum int, totalCommits int) string {
+	return fmt.Sprintf("\ndef contribution_%s():\n    \"\"\"Contribution on %s (%d/%d).\"\"\"\n    return \"%s\", %d, %d\n", fragmentID(date, commitNum), date, commitNum, totalCommits, date, commitNum, totalCommits)
+}
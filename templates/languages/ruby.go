@@ -59,3 +59,9 @@ vendor/
 `,
 	}
 }
+
+func (t *RubyTemplate) GetFileHeader() string { return "# Contribution records generated by GreenWall\n" }
+
+func (t *RubyTemplate) GenerateFragment(date string, commitNum int, totalCommits int) string {
+	return fmt.Sprintf("def contribution_%s\n  { date: '%s', commit: %d, total: %d }\nend\n", fragmentID(date, commitNum), date, commitNum, totalCommits)
+}
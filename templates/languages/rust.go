@@ -86,3 +86,9 @@ Cargo.lock
 `,
 	}
 }
+
+func (t *RustTemplate) GetFileHeader() string { return "//! Contribution records generated by GreenWall.\n" }
+
+func (t *RustTemplate) GenerateFragment(date string, commitNum int, totalCommits int) string {
+	return fmt.Sprintf("/// Contribution on %s (%d/%d).\npub fn contribution_%s() -> (&'static str, u32, u32) {\n    (\"%s\", %d, %d)\n}\n", date, commitNum, totalCommits, fragmentID(date, commitNum), date, commitNum, totalCommits)
+}
@@ -60,3 +60,9 @@ func (t *SCSSTemplate) GetAdditionalFiles(repoName string) map[string]string {
 `,
 	}
 }
+
+func (t *SCSSTemplate) GetFileHeader() string { return "// Contribution records generated by GreenWall\n" }
+
+func (t *SCSSTemplate) GenerateFragment(date string, commitNum int, totalCommits int) string {
+	return fmt.Sprintf("$contribution-%s: (date: \"%s\", commit: %d, total: %d);\n", fragmentID(date, commitNum), date, commitNum, totalCommits)
+}
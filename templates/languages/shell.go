@@ -38,3 +38,9 @@ func (t *ShellTemplate) GetAdditionalFiles(repoName string) map[string]string {
 `,
 	}
 }
+
+func (t *ShellTemplate) GetFileHeader() string { return "#!/usr/bin/env bash\n# Contribution records generated by GreenWall\n" }
+
+func (t *ShellTemplate) GenerateFragment(date string, commitNum int, totalCommits int) string {
+	return fmt.Sprintf("contribution_%s() {\n  echo \"%s %d/%d\"\n}\n", fragmentID(date, commitNum), date, commitNum, totalCommits)
+}
@@ -43,3 +43,9 @@ func (t *SQLTemplate) GetAdditionalFiles(repoName string) map[string]string {
 `,
 	}
 }
+
+func (t *SQLTemplate) GetFileHeader() string { return "-- Contribution records generated by GreenWall\nCREATE TABLE IF NOT EXISTS contributions (\n    date DATE,\n    commit_number INT,\n    total_commits INT\n);\n" }
+
+func (t *SQLTemplate) GenerateFragment(date string, commitNum int, totalCommits int) string {
+	return fmt.Sprintf("INSERT INTO contributions (date, commit_number, total_commits) VALUES ('%s', %d, %d);\n", date, commitNum, totalCommits)
+}
@@ -71,3 +71,9 @@ let package = Package(
 `,
 	}
 }
+
+func (t *SwiftTemplate) GetFileHeader() string { return "// Contribution records generated by GreenWall\n" }
+
+func (t *SwiftTemplate) GenerateFragment(date string, commitNum int, totalCommits int) string {
+	return fmt.Sprintf("func contribution%s() -> (String, Int, Int) {\n    return (\"%s\", %d, %d)\n}\n", fragmentID(date, commitNum), date, commitNum, totalCommits)
+}
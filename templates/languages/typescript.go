@@ -103,3 +103,9 @@ yarn-error.log
 `,
 	}
 }
+
+func (t *TypeScriptTemplate) GetFileHeader() string { return "// Contribution records generated by GreenWall\n\nexport interface ContributionRecord {\n  date: string;\n  commit: number;\n  total: number;\n}\n" }
+
+func (t *TypeScriptTemplate) GenerateFragment(date string, commitNum int, totalCommits int) string {
+	return fmt.Sprintf("export function contribution_%s(): ContributionRecord {\n  return { date: '%s', commit: %d, total: %d };\n}\n", fragmentID(date, commitNum), date, commitNum, totalCommits)
+}
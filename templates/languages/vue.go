@@ -75,3 +75,9 @@ dist/
 `,
 	}
 }
+
+func (t *VueTemplate) GetFileHeader() string { return "<!-- Contribution records generated by GreenWall -->\n" }
+
+func (t *VueTemplate) GenerateFragment(date string, commitNum int, totalCommits int) string {
+	return fmt.Sprintf("<template id=\"contribution-%s\">\n  <p>Contribution on %s (%d/%d)</p>\n</template>\n", fragmentID(date, commitNum), date, commitNum, totalCommits)
+}
@@ -0,0 +1,179 @@
+// text_banner.go 将文本排版为贡献数据，排版逻辑位于 banner 包，本文件负责与前端及命令行对接。
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"go.uber.org/zap"
+	"green-wall/banner"
+)
+
+// userFontsOnce 保证用户字体目录只扫描一次。
+var userFontsOnce sync.Once
+
+// TextBannerRequest 定义文本排版请求。
+type TextBannerRequest struct {
+	Text     string `json:"text"`     // 要显示的文本
+	Year     int    `json:"year"`     // 起始年份
+	Font     string `json:"font"`     // 字体名称，默认 5x7，可使用 ListBannerFonts 返回的任意字体
+	Spacing  int    `json:"spacing"`  // 在默认 1 列字间距基础上增加的列数
+	Kerning  bool   `json:"kerning"`  // 按字形实际宽度排版
+	Align    string `json:"align"`    // left/center/right，默认 center
+	Level    int    `json:"level"`    // 文字色阶 (1-4)，默认 4
+	Scroll   bool   `json:"scroll"`   // 一年放不下时是否顺延到之后的年份
+	MaxYears int    `json:"maxYears"` // 最多使用的年份数，0 表示不限制
+}
+
+// TextBannerPage 描述文本在某一年中显示的片段。
+type TextBannerPage struct {
+	Year  int    `json:"year"`  // 年份
+	Text  string `json:"text"`  // 该年显示的文本
+	Width int    `json:"width"` // 文本占用的列数
+}
+
+// TextBannerResponse 返回排版结果。
+type TextBannerResponse struct {
+	Pages         []TextBannerPage    `json:"pages"`         // 每一年显示的文本片段
+	Years         []YearContributions `json:"years"`         // 按年份分组的贡献数据，可直接用于 GenerateMultiYearRepo
+	Contributions []ContributionDay   `json:"contributions"` // 所有年份合并后的贡献数据
+	TotalCommits  int                 `json:"totalCommits"`  // 提交总数
+}
+
+// RenderTextBanner 将文本排版为一个或多个年份的贡献数据。
+func (a *App) RenderTextBanner(req TextBannerRequest) (*TextBannerResponse, error) {
+	LogInfo("开始排版文本",
+		zap.String("text", req.Text),
+		zap.Int("year", req.Year),
+		zap.String("font", req.Font),
+		zap.Bool("scroll", req.Scroll))
+
+	resp, err := renderTextBanner(req)
+	if err != nil {
+		LogError("文本排版失败", zap.Error(err))
+		return nil, err
+	}
+
+	LogInfo("文本排版完成", zap.Int("years", len(resp.Years)), zap.Int("total_commits", resp.TotalCommits))
+	return resp, nil
+}
+
+// ListBannerFonts 返回可用于文本排版的字体名称，包括内置字体与用户导入的字体。
+func (a *App) ListBannerFonts() []string {
+	loadUserBannerFonts()
+	return banner.FontNames()
+}
+
+// ImportBannerFont 导入 BDF/PSF 字体：解析成功后复制到用户字体目录并注册，返回字体名称。
+// filePath 为空时弹出文件选择对话框。
+func (a *App) ImportBannerFont(filePath string) (string, error) {
+	if strings.TrimSpace(filePath) == "" {
+		var err error
+		filePath, err = runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+			Title: "选择点阵字体",
+			Filters: []runtime.FileFilter{
+				{DisplayName: "点阵字体 (*.bdf, *.psf, *.psf.gz)", Pattern: "*.bdf;*.psf;*.psf.gz"},
+			},
+		})
+		if err != nil {
+			LogError("打开文件对话框失败", zap.Error(err))
+			return "", fmt.Errorf("open file dialog: %w", err)
+		}
+		if filePath == "" {
+			LogInfo("用户取消了字体导入")
+			return "", fmt.Errorf("import cancelled")
+		}
+	}
+
+	font, err := banner.LoadFontFile(filePath)
+	if err != nil {
+		LogError("解析字体失败", zap.String("path", filePath), zap.Error(err))
+		return "", err
+	}
+	if err := banner.Register(font); err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("read font: %w", err)
+	}
+	dir := bannerFontDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create font directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, filepath.Base(filePath)), data, 0o644); err != nil {
+		LogError("保存字体失败", zap.Error(err))
+		return "", fmt.Errorf("save font: %w", err)
+	}
+
+	LogInfo("字体导入成功", zap.String("font", font.Name), zap.Int("height", font.Height), zap.Int("glyphs", len(font.Glyphs)))
+	return font.Name, nil
+}
+
+// bannerFontDir 返回用户导入字体的存放目录。
+func bannerFontDir() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		configDir = os.TempDir()
+	}
+	return filepath.Join(configDir, "green-wall", "fonts")
+}
+
+// loadUserBannerFonts 注册用户字体目录中的所有 BDF/PSF 字体，无法解析的文件记录警告后跳过。
+func loadUserBannerFonts() {
+	userFontsOnce.Do(func() {
+		entries, err := os.ReadDir(bannerFontDir())
+		if err != nil {
+			return
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			path := filepath.Join(bannerFontDir(), entry.Name())
+			font, err := banner.LoadFontFile(path)
+			if err == nil {
+				err = banner.Register(font)
+			}
+			if err != nil {
+				LogWarn("加载用户字体失败", zap.String("path", path), zap.Error(err))
+			}
+		}
+	})
+}
+
+// renderTextBanner 调用 banner 包排版文本，并将色阶转换为前端画笔对应的提交数。
+func renderTextBanner(req TextBannerRequest) (*TextBannerResponse, error) {
+	loadUserBannerFonts()
+	pages, err := banner.Layout(req.Text, req.Year, banner.Options{
+		Font:     req.Font,
+		Spacing:  req.Spacing,
+		Kerning:  req.Kerning,
+		Align:    banner.Align(req.Align),
+		Level:    req.Level,
+		Scroll:   req.Scroll,
+		MaxYears: req.MaxYears,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("layout text: %w", err)
+	}
+
+	resp := &TextBannerResponse{}
+	for _, page := range pages {
+		resp.Pages = append(resp.Pages, TextBannerPage{Year: page.Year, Text: page.Text, Width: page.Width})
+		year := YearContributions{Year: page.Year}
+		for _, day := range page.Days {
+			c := ContributionDay{Date: day.Date, Count: drawingCountForLevel(day.Level)}
+			year.Contributions = append(year.Contributions, c)
+			resp.Contributions = append(resp.Contributions, c)
+			resp.TotalCommits += c.Count
+		}
+		resp.Years = append(resp.Years, year)
+	}
+	return resp, nil
+}
@@ -0,0 +1,207 @@
+// workspace.go 管理生成仓库的工作区：每个生成的仓库以项目名保存在固定目录中，
+// 可以列出、重新打开、重试推送或删除，推送失败时不会丢失已生成的历史。
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// workspaceMetadataFile 是项目元数据在仓库 .git 目录中的文件名，放在 .git 内以免出现在工作区变更中。
+const workspaceMetadataFile = "greenwall.json"
+
+// 工作区仓库的状态。
+const (
+	workspaceStatusGenerated  = "generated"   // 已生成，尚未推送
+	workspaceStatusPushed     = "pushed"      // 推送成功
+	workspaceStatusPushFailed = "push_failed" // 推送失败，可重试
+)
+
+// WorkspaceRepo 描述工作区中的一个生成仓库。
+type WorkspaceRepo struct {
+	Name          string           `json:"name"`                // 项目名（目录名）
+	Path          string           `json:"path"`                // 本地路径
+	Year          int              `json:"year"`                // 生成时的年份，跨年份生成时为第一个年份
+	Years         []int            `json:"years,omitempty"`     // 跨年份生成时覆盖的全部年份
+	CommitCount   int              `json:"commitCount"`         // 生成的提交数
+	Status        string           `json:"status"`              // generated / pushed / push_failed
+	CreatedAt     string           `json:"createdAt"`           // 生成时间 (RFC3339)
+	LastPushAt    string           `json:"lastPushAt"`          // 最近一次推送时间 (RFC3339)
+	LastPushError string           `json:"lastPushError"`       // 最近一次推送失败的原因
+	LastPush      *PushRepoRequest `json:"lastPush,omitempty"`  // 最近一次推送的参数，用于重试
+	RemoteURL     string           `json:"remoteUrl,omitempty"` // 推送成功后的仓库地址
+}
+
+// defaultWorkspacePath 返回工作区目录：用户配置目录下的 green-wall/workspace。
+func defaultWorkspacePath() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "green-wall")
+	}
+	return filepath.Join(configDir, "green-wall", "workspace")
+}
+
+// GetWorkspacePath 返回工作区所在的目录。
+func (a *App) GetWorkspacePath() string {
+	return a.repoBasePath
+}
+
+// ListWorkspaceRepos 列出工作区中的所有仓库，最近生成的排在前面。
+func (a *App) ListWorkspaceRepos() ([]WorkspaceRepo, error) {
+	entries, err := os.ReadDir(a.repoBasePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []WorkspaceRepo{}, nil
+		}
+		return nil, fmt.Errorf("read workspace: %w", err)
+	}
+
+	repos := []WorkspaceRepo{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		repo, err := a.loadWorkspaceRepo(entry.Name())
+		if err != nil {
+			// 不是由本应用生成的目录
+			continue
+		}
+		repos = append(repos, *repo)
+	}
+	sort.SliceStable(repos, func(i, j int) bool { return repos[i].CreatedAt > repos[j].CreatedAt })
+	return repos, nil
+}
+
+// OpenWorkspaceRepo 在系统文件管理器中打开工作区中的仓库。
+func (a *App) OpenWorkspaceRepo(name string) error {
+	repo, err := a.loadWorkspaceRepo(name)
+	if err != nil {
+		return err
+	}
+	return openDirectory(repo.Path)
+}
+
+// DeleteWorkspaceRepo 删除工作区中的仓库，远程仓库不受影响。
+func (a *App) DeleteWorkspaceRepo(name string) error {
+	repo, err := a.loadWorkspaceRepo(name)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(repo.Path); err != nil {
+		LogError("删除工作区仓库失败", zap.String("path", repo.Path), zap.Error(err))
+		return fmt.Errorf("remove workspace repo: %w", err)
+	}
+	LogInfo("删除工作区仓库", zap.String("name", repo.Name))
+	return nil
+}
+
+// RetryPush 使用上一次的推送参数重新推送工作区中的仓库。
+func (a *App) RetryPush(name string) (*PushRepoResponse, error) {
+	repo, err := a.loadWorkspaceRepo(name)
+	if err != nil {
+		return nil, err
+	}
+	if repo.LastPush == nil {
+		return nil, fmt.Errorf("workspace repo %q has not been pushed before", repo.Name)
+	}
+	req := *repo.LastPush
+	req.RepoPath = repo.Path
+	LogInfo("重试推送", zap.String("name", repo.Name), zap.String("repo_name", req.RepoName))
+	return a.PushToGitHub(req)
+}
+
+// createWorkspaceRepo 在工作区中创建以项目名命名的目录，同名目录已存在时追加数字后缀。
+func (a *App) createWorkspaceRepo(name string) (string, error) {
+	if err := os.MkdirAll(a.repoBasePath, 0o755); err != nil {
+		return "", fmt.Errorf("create workspace: %w", err)
+	}
+	candidate := name
+	for i := 2; ; i++ {
+		path := filepath.Join(a.repoBasePath, candidate)
+		err := os.Mkdir(path, 0o755)
+		if err == nil {
+			return path, nil
+		}
+		if !os.IsExist(err) {
+			return "", err
+		}
+		candidate = fmt.Sprintf("%s-%d", name, i)
+	}
+}
+
+// workspaceRepoPath 将项目名解析为工作区内的路径，拒绝逃逸出工作区的名称。
+func (a *App) workspaceRepoPath(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || name != filepath.Base(name) || name == "." || name == ".." {
+		return "", fmt.Errorf("invalid workspace repo name %q", name)
+	}
+	return filepath.Join(a.repoBasePath, name), nil
+}
+
+// loadWorkspaceRepo 读取工作区仓库的元数据。
+func (a *App) loadWorkspaceRepo(name string) (*WorkspaceRepo, error) {
+	path, err := a.workspaceRepoPath(name)
+	if err != nil {
+		return nil, err
+	}
+	return readWorkspaceMetadata(path)
+}
+
+// readWorkspaceMetadata 读取仓库 .git 目录中的元数据。
+func readWorkspaceMetadata(repoPath string) (*WorkspaceRepo, error) {
+	data, err := os.ReadFile(filepath.Join(repoPath, ".git", workspaceMetadataFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("workspace repo %q not found", filepath.Base(repoPath))
+		}
+		return nil, fmt.Errorf("read workspace metadata: %w", err)
+	}
+	var repo WorkspaceRepo
+	if err := json.Unmarshal(data, &repo); err != nil {
+		return nil, fmt.Errorf("parse workspace metadata: %w", err)
+	}
+	repo.Name = filepath.Base(repoPath)
+	repo.Path = repoPath
+	return &repo, nil
+}
+
+// writeWorkspaceMetadata 将元数据写入仓库的 .git 目录。
+func writeWorkspaceMetadata(repo *WorkspaceRepo) error {
+	data, err := json.MarshalIndent(repo, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal workspace metadata: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(repo.Path, ".git", workspaceMetadataFile), data, 0o644); err != nil {
+		return fmt.Errorf("write workspace metadata: %w", err)
+	}
+	return nil
+}
+
+// recordPushResult 在推送结束后更新工作区元数据；不是工作区仓库时忽略。
+func recordPushResult(req PushRepoRequest, remoteURL string, pushErr string) {
+	repo, err := readWorkspaceMetadata(req.RepoPath)
+	if err != nil {
+		return
+	}
+	stored := req
+	stored.RepoPath = ""
+	repo.LastPush = &stored
+	repo.LastPushAt = time.Now().Format(time.RFC3339)
+	repo.LastPushError = pushErr
+	if pushErr == "" {
+		repo.Status = workspaceStatusPushed
+		repo.RemoteURL = remoteURL
+	} else {
+		repo.Status = workspaceStatusPushFailed
+	}
+	if err := writeWorkspaceMetadata(repo); err != nil {
+		LogWarn("更新工作区元数据失败", zap.String("path", req.RepoPath), zap.Error(err))
+	}
+}